@@ -0,0 +1,21 @@
+package main
+
+import (
+	"errors"
+
+	"go.mattglei.ch/timber"
+)
+
+func main() {
+	timber.Async(timber.AsyncOptions{
+		BufferSize: 256,
+		Overflow:   timber.OverflowDropOldest,
+	})
+	defer timber.Close()
+
+	for i := range 5 {
+		timber.Info("processed item", timber.A("item", i))
+	}
+	timber.Error(errors.New("connection reset"), "failed to send batch")
+	timber.Done("finished", timber.A("dropped", timber.DroppedEntries()))
+}
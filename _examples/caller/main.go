@@ -0,0 +1,26 @@
+package main
+
+import (
+	"log/slog"
+
+	"go.mattglei.ch/timber"
+)
+
+func main() {
+	timber.ShowCaller(true)
+	timber.Info("server listening", timber.A("port", 8080))
+	logf("using a wrapper")
+
+	timber.ShowCallerFunction(true)
+	slog.New(timber.NewSlogHandler(nil)).Info("logged with slog")
+
+	timber.Structured(true)
+	timber.Info("server listening", timber.A("port", 8080))
+}
+
+// logf is a wrapper around timber so one extra frame is skipped to report the
+// location that called logf
+func logf(msg string) {
+	logger := timber.New(timber.WithShowCaller(true), timber.WithCallerSkip(1))
+	logger.Info(msg)
+}
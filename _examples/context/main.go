@@ -0,0 +1,21 @@
+package main
+
+import (
+	"context"
+
+	"go.mattglei.ch/timber"
+)
+
+func main() {
+	ctx := timber.WithContext(context.Background(), timber.A("trace_id", "4bf92f35"))
+	handle(timber.WithContext(ctx, timber.A("tenant", "acme")))
+}
+
+func handle(ctx context.Context) {
+	timber.InfoContext(ctx, "handling request", timber.A("path", "/"))
+	loadUser(ctx)
+}
+
+func loadUser(ctx context.Context) {
+	timber.FromContext(ctx).Done("loaded user", timber.A("id", 42))
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"go.mattglei.ch/timber"
+)
+
+func main() {
+	sink, err := timber.FileSink("logs/app.log", timber.FileOptions{
+		MaxSize:        1024,
+		MaxBackups:     3,
+		Compress:       true,
+		ReopenOnSIGHUP: true,
+	})
+	if err != nil {
+		timber.Fatal(err, "failed to open log file")
+	}
+	defer sink.Writer.(*timber.RotatingFile).Close()
+	timber.AddSink(sink)
+
+	for i := range 100 {
+		timber.Info("processed item", timber.A("item", i))
+	}
+	timber.Done("finished", timber.A("log", "logs/app.log"))
+
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	"go.mattglei.ch/timber"
+)
+
+func main() {
+	timber.Format(timber.JSON)
+
+	start := time.Now()
+	timber.Info("server listening", timber.A("port", 8080), timber.A("tls", true))
+	timber.DoneSince(start, "loaded config", timber.A("paths", []string{"a.toml", "b.toml"}))
+	timber.Error(
+		errors.New("connection refused"),
+		"failed to connect",
+		timber.A("upstream", map[string]any{"host": "localhost", "port": 5432}),
+	)
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"io"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"go.mattglei.ch/timber"
+)
+
+const (
+	SeverityTrace timber.Severity = 5
+	SeverityAudit timber.Severity = 45
+)
+
+func main() {
+	timber.DefineLevel(timber.LevelDefinition{
+		Severity: SeverityTrace,
+		Name:     "TRACE",
+		Style:    lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#8A8A8A")),
+	})
+	timber.DefineLevel(timber.LevelDefinition{
+		Severity:  SeverityAudit,
+		Name:      "AUDIT",
+		Style:     lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#B48EFF")),
+		Writers:   []io.Writer{os.Stdout, os.Stderr},
+		ShowStack: true,
+	})
+	timber.SetMinLevel(SeverityTrace)
+	timber.SetDebugStyle(lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#00FFFF")))
+
+	timber.Log(SeverityTrace, "entering handler", timber.A("path", "/"))
+	timber.Debug("loaded session")
+	timber.Log(SeverityAudit, "user deleted", timber.A("user", 42))
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	"go.mattglei.ch/timber"
+)
+
+func main() {
+	db := timber.New(
+		timber.WithTimeFormat("15:04:05"),
+		timber.WithTimezone(time.Local),
+	)
+	api := timber.New(timber.WithStructured(true), timber.WithOut(os.Stderr))
+
+	db.Info("connected to database", timber.A("host", "localhost"))
+	api.Info("server listening", timber.A("port", 8080))
+	timber.Info("using the default logger")
+}
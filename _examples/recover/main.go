@@ -0,0 +1,26 @@
+package main
+
+import (
+	"sync"
+
+	"go.mattglei.ch/timber"
+)
+
+func main() {
+	timber.OnPanic(timber.PanicSwallow)
+
+	handle(nil)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	timber.Go(func() {
+		defer wg.Done()
+		panic("something went wrong")
+	})
+	wg.Wait()
+}
+
+func handle(user *struct{ name string }) {
+	defer timber.Recover("failed to handle request", timber.A("path", "/"))
+	println(user.name)
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"errors"
+	"os"
+
+	"go.mattglei.ch/timber"
+)
+
+func main() {
+	// warnings are written to both stdout and stderr
+	timber.Route(timber.SeverityWarning, os.Stdout, os.Stderr)
+	timber.Warning("disk almost full", timber.A("used", "91%"))
+
+	// everything is written to stderr, styled for stderr's color profile
+	timber.SetRoutes(timber.Routes{
+		timber.SeverityDebug:   {os.Stderr},
+		timber.SeverityInfo:    {os.Stderr},
+		timber.SeverityDone:    {os.Stderr},
+		timber.SeverityWarning: {os.Stderr},
+		timber.SeverityError:   {os.Stderr},
+		timber.SeverityFatal:   {os.Stderr},
+	})
+	timber.Info("server listening", timber.A("port", 8080))
+	timber.Error(errors.New("connection refused"), "failed to connect")
+}
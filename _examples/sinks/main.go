@@ -0,0 +1,26 @@
+package main
+
+import (
+	"errors"
+	"os"
+
+	"go.mattglei.ch/timber"
+)
+
+func main() {
+	file, err := os.Create("app.log")
+	if err != nil {
+		timber.Fatal(err, "failed to create log file")
+	}
+	defer file.Close()
+
+	timber.AddSink(timber.Sink{
+		Writer:   file,
+		MinLevel: timber.SeverityInfo,
+		Format:   timber.JSON,
+	})
+
+	timber.Debug("only written to the terminal")
+	timber.Info("server listening", timber.A("port", 8080))
+	timber.Error(errors.New("connection refused"), "failed to connect")
+}
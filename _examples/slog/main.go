@@ -0,0 +1,20 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+
+	"go.mattglei.ch/timber"
+)
+
+func main() {
+	logger := slog.New(timber.NewSlogHandler(nil))
+
+	logger.Debug("loaded home dir", "path", "/home/matt")
+	logger.Info("server listening", "port", 8080)
+	logger.Log(context.Background(), timber.SlogLevelDone, "finished migrations", "count", 3)
+
+	req := logger.With("request_id", "a1b2").WithGroup("http")
+	req.Warn("slow request", "method", "GET", slog.Group("timing", "ms", 1200))
+	req.Error("request failed", "status", 500)
+}
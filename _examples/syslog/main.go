@@ -0,0 +1,26 @@
+package main
+
+import "go.mattglei.ch/timber"
+
+func main() {
+	journal, err := timber.JournaldSink(timber.JournaldOptions{Identifier: "example"})
+	if err == nil {
+		defer journal.Writer.(*timber.SocketWriter).Close()
+		timber.AddSink(journal)
+	} else {
+		timber.Warning("journald isn't available", timber.A("error", err))
+	}
+
+	syslog, err := timber.SyslogSink("udp", "localhost:514", timber.SyslogOptions{
+		AppName:  "example",
+		MinLevel: timber.SeverityWarning,
+	})
+	if err != nil {
+		timber.Fatal(err, "failed to connect to syslog")
+	}
+	defer syslog.Writer.(*timber.SocketWriter).Close()
+	timber.AddSink(syslog)
+
+	timber.Info("server listening", timber.A("port", 8080))
+	timber.Warning("slow request", timber.A("path", "/"), timber.A("ms", 1200))
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	"go.mattglei.ch/timber"
+)
+
+func main() {
+	timber.LogTimerStart(true)
+	timber.TimerThreshold(50 * time.Millisecond)
+
+	t := timber.Start("loading config", timber.A("path", "config.toml"))
+	time.Sleep(10 * time.Millisecond)
+	t.Lap("parsed")
+	time.Sleep(20 * time.Millisecond)
+	t.Lap("validated")
+	t.Done()
+
+	slow := timber.Start("running migrations")
+	time.Sleep(60 * time.Millisecond)
+	slow.Done(timber.A("count", 3))
+
+	failed := timber.Start("connecting to database")
+	time.Sleep(5 * time.Millisecond)
+	failed.Error(errors.New("connection refused"), timber.A("host", "localhost"))
+}
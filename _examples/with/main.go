@@ -0,0 +1,20 @@
+package main
+
+import (
+	"errors"
+
+	"go.mattglei.ch/timber"
+)
+
+func main() {
+	logger := timber.With(timber.A("request_id", "a1b2"), timber.A("user", 42))
+
+	logger.Info("handling request", timber.A("path", "/"))
+	logger.With(timber.A("db", "postgres")).Warning("slow query")
+	logger.ErrorMsg("request failed")
+
+	logger.Structured(true)
+	logger.Error(errors.New("timeout"), "request failed", timber.A("path", "/"))
+	logger.Format(timber.JSON)
+	logger.Info("handled request")
+}
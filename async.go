@@ -0,0 +1,248 @@
+package timber
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy is what happens when an entry is logged while the async buffer
+// is full
+type OverflowPolicy int
+
+const (
+	// Wait for the writer goroutine to make room in the buffer
+	OverflowBlock OverflowPolicy = iota
+	// Drop the entry that is being logged
+	OverflowDropNewest
+	// Drop the oldest entry in the buffer to make room
+	OverflowDropOldest
+)
+
+// AsyncOptions configures asynchronous output
+type AsyncOptions struct {
+	// Number of entries that can be waiting to be written. Defaults to 1024.
+	BufferSize int
+	// What happens when an entry is logged while the buffer is full
+	Overflow OverflowPolicy
+}
+
+type asyncRecord struct {
+	writer io.Writer
+	// the sink's mutex since loggers without async output can share the writer
+	mutex *sync.Mutex
+	line  string
+}
+
+// asyncWriter is a bounded ring buffer of entries that is drained by a writer
+// goroutine
+type asyncWriter struct {
+	mutex    sync.Mutex
+	notEmpty sync.Cond
+	notFull  sync.Cond
+	idle     sync.Cond
+	records  []asyncRecord
+	head     int
+	count    int
+	writing  bool
+	closed   bool
+	overflow OverflowPolicy
+	dropped  *atomic.Uint64
+	done     chan struct{}
+}
+
+func newAsyncWriter(opts AsyncOptions, dropped *atomic.Uint64) *asyncWriter {
+	size := opts.BufferSize
+	if size <= 0 {
+		size = 1024
+	}
+	a := &asyncWriter{
+		records:  make([]asyncRecord, size),
+		overflow: opts.Overflow,
+		dropped:  dropped,
+		done:     make(chan struct{}),
+	}
+	a.notEmpty.L = &a.mutex
+	a.notFull.L = &a.mutex
+	a.idle.L = &a.mutex
+	go a.run()
+	return a
+}
+
+// enqueue adds a line to the buffer to be written to a sink. It returns false
+// without writing if the writer has been closed, which can happen to loggers
+// created with With.
+func (a *asyncWriter) enqueue(s *sink, line string) bool {
+	a.mutex.Lock()
+	for a.count == len(a.records) && !a.closed {
+		switch a.overflow {
+		case OverflowDropNewest:
+			a.mutex.Unlock()
+			a.dropped.Add(1)
+			return true
+		case OverflowDropOldest:
+			a.records[a.head] = asyncRecord{}
+			a.head = (a.head + 1) % len(a.records)
+			a.count--
+			a.dropped.Add(1)
+		default:
+			a.notFull.Wait()
+		}
+	}
+	if a.closed {
+		a.mutex.Unlock()
+		return false
+	}
+	a.records[(a.head+a.count)%len(a.records)] = asyncRecord{
+		writer: s.Writer,
+		mutex:  s.mutex,
+		line:   line,
+	}
+	a.count++
+	a.notEmpty.Signal()
+	a.mutex.Unlock()
+	return true
+}
+
+func (a *asyncWriter) run() {
+	defer close(a.done)
+	batch := make([]asyncRecord, 0, len(a.records))
+	for {
+		a.mutex.Lock()
+		for a.count == 0 && !a.closed {
+			a.notEmpty.Wait()
+		}
+		if a.count == 0 {
+			a.mutex.Unlock()
+			return
+		}
+		for a.count > 0 {
+			batch = append(batch, a.records[a.head])
+			a.records[a.head] = asyncRecord{}
+			a.head = (a.head + 1) % len(a.records)
+			a.count--
+		}
+		a.writing = true
+		a.notFull.Broadcast()
+		a.mutex.Unlock()
+
+		for _, record := range batch {
+			record.mutex.Lock()
+			_, _ = io.WriteString(record.writer, record.line)
+			record.mutex.Unlock()
+		}
+		clear(batch)
+		batch = batch[:0]
+
+		a.mutex.Lock()
+		a.writing = false
+		if a.count == 0 {
+			a.idle.Broadcast()
+		}
+		a.mutex.Unlock()
+	}
+}
+
+// wait blocks until every buffered entry has been written
+func (a *asyncWriter) wait() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	for a.count > 0 || a.writing {
+		a.idle.Wait()
+	}
+}
+
+// close writes every buffered entry and then stops the writer goroutine
+func (a *asyncWriter) close() {
+	a.mutex.Lock()
+	a.closed = true
+	a.notEmpty.Broadcast()
+	a.notFull.Broadcast()
+	a.mutex.Unlock()
+	<-a.done
+}
+
+// Write entries from a background goroutine instead of while logging. Entries
+// are held in a bounded buffer until they are written. Call Close before the
+// program exits so buffered entries aren't lost.
+//
+// Default is synchronous output
+func (l *Logger) Async(opts AsyncOptions) {
+	l.mutex.Lock()
+	previous := l.async
+	l.async = newAsyncWriter(opts, l.dropped)
+	l.mutex.Unlock()
+	if previous != nil {
+		previous.close()
+	}
+}
+
+// Flush blocks until every buffered entry has been written and then flushes the
+// outputs
+func (l *Logger) Flush() {
+	l.mutex.RLock()
+	var (
+		async   = l.async
+		writers = l.writers()
+	)
+	l.mutex.RUnlock()
+	if async != nil {
+		async.wait()
+	}
+	for _, w := range writers {
+		flushWriter(w)
+	}
+}
+
+// Close writes every buffered entry, stops the background writer, and flushes
+// the outputs. Entries logged after Close are written synchronously.
+func (l *Logger) Close() {
+	l.mutex.Lock()
+	async := l.async
+	l.async = nil
+	l.mutex.Unlock()
+	if async != nil {
+		async.close()
+	}
+	l.Flush()
+}
+
+// DroppedEntries gets the number of entries that have been dropped because the
+// async buffer was full. The count is kept across calls to Async and Close.
+func (l *Logger) DroppedEntries() uint64 {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return l.dropped.Load()
+}
+
+// Write entries from a background goroutine instead of while logging. Entries
+// are held in a bounded buffer until they are written. Call Close before the
+// program exits so buffered entries aren't lost.
+//
+// Default is synchronous output
+func Async(opts AsyncOptions) {
+	globalLogger.Async(opts)
+}
+
+// Flush blocks until every buffered entry has been written and then flushes the
+// outputs
+func Flush() {
+	globalLogger.Flush()
+}
+
+// Close writes every buffered entry, stops the background writer, and flushes
+// the outputs. Entries logged after Close are written synchronously.
+func Close() {
+	globalLogger.Close()
+}
+
+// DroppedEntries gets the number of entries that have been dropped because the
+// async buffer was full. The count is kept across calls to Async and Close.
+func DroppedEntries() uint64 {
+	return globalLogger.DroppedEntries()
+}
+
+// Option to write entries from a background goroutine.
+func WithAsync(opts AsyncOptions) Option {
+	return func(l *Logger) { l.Async(opts) }
+}
@@ -0,0 +1,149 @@
+package timber
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// slowWriter counts the lines written to it, taking a while for each one
+type slowWriter struct {
+	mutex sync.Mutex
+	lines int
+}
+
+func (w *slowWriter) Write(p []byte) (int, error) {
+	time.Sleep(100 * time.Microsecond)
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.lines += bytes.Count(p, []byte("\n"))
+	return len(p), nil
+}
+
+func TestAsyncOverflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		overflow OverflowPolicy
+		dropping bool
+	}{
+		{"block", OverflowBlock, false},
+		{"drop newest", OverflowDropNewest, true},
+		{"drop oldest", OverflowDropOldest, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &slowWriter{}
+			l := New(
+				WithOut(w),
+				WithAsync(AsyncOptions{BufferSize: 4, Overflow: tt.overflow}),
+			)
+			const logged = 100
+			for range logged {
+				l.Info("entry")
+			}
+			l.Close()
+
+			dropped := l.DroppedEntries()
+			if got := uint64(w.lines) + dropped; got != logged {
+				t.Errorf("written + dropped = %d, want %d", got, logged)
+			}
+			if (dropped != 0) != tt.dropping {
+				t.Errorf("dropped %d entries with dropping = %v", dropped, tt.dropping)
+			}
+		})
+	}
+}
+
+func TestDroppedEntriesKeptAcrossAsync(t *testing.T) {
+	l := New(WithOut(&slowWriter{}))
+	l.Async(AsyncOptions{BufferSize: 1, Overflow: OverflowDropNewest})
+	for range 20 {
+		l.Info("entry")
+	}
+	l.Close()
+	dropped := l.DroppedEntries()
+	if dropped == 0 {
+		t.Fatal("expected entries to be dropped")
+	}
+	l.Async(AsyncOptions{})
+	defer l.Close()
+	if got := l.DroppedEntries(); got != dropped {
+		t.Errorf("DroppedEntries() = %d after Async, want %d", got, dropped)
+	}
+}
+
+// lockedBuffer fails the test if it is written to concurrently
+type lockedBuffer struct {
+	t       *testing.T
+	writing sync.Mutex
+	buf     bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	if !b.writing.TryLock() {
+		b.t.Error("concurrent write")
+		return len(p), nil
+	}
+	defer b.writing.Unlock()
+	return b.buf.Write(p)
+}
+
+func TestAsyncClosedChildWritesAreSerialized(t *testing.T) {
+	out := &lockedBuffer{t: t}
+	l := New(WithOut(out), WithDisplayTime(false), WithAsync(AsyncOptions{}))
+	child := l.With(A("child", true))
+	l.Close()
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 50 {
+				child.Info("after close")
+			}
+		})
+	}
+	wg.Wait()
+	if got := strings.Count(out.buf.String(), "after close"); got != 400 {
+		t.Errorf("wrote %d entries, want 400", got)
+	}
+}
+
+func BenchmarkSync(b *testing.B) {
+	l := New(WithOut(io.Discard), WithDisplayTime(false))
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			l.Info("server listening", A("port", 8080))
+		}
+	})
+}
+
+func BenchmarkAsync(b *testing.B) {
+	policies := []struct {
+		name     string
+		overflow OverflowPolicy
+	}{
+		{"Block", OverflowBlock},
+		{"DropNewest", OverflowDropNewest},
+		{"DropOldest", OverflowDropOldest},
+	}
+	for _, p := range policies {
+		b.Run(p.name, func(b *testing.B) {
+			l := New(
+				WithOut(io.Discard),
+				WithDisplayTime(false),
+				WithAsync(AsyncOptions{Overflow: p.overflow}),
+			)
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					l.Info("server listening", A("port", 8080))
+				}
+			})
+			l.Close()
+		})
+	}
+}
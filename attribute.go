@@ -1,5 +1,7 @@
 package timber
 
+import "slices"
+
 // Attr is a key-value pair attached to a log entry for additional context.
 type Attr struct {
 	Key   string
@@ -10,3 +12,71 @@ type Attr struct {
 func A(key string, value any) Attr {
 	return Attr{Key: key, Value: value}
 }
+
+// attributes nested under the key of a group Attr
+type group []Attr
+
+// Group creates an Attr that nests the given attributes under key.
+//
+// Plain logs render it as key: {a: 1, b: 2}, structured logs as key.a=1
+// key.b=2, and JSON logs as a nested object.
+func Group(key string, attrs ...Attr) Attr {
+	return Attr{Key: key, Value: group(attrs)}
+}
+
+// MarshalJSON encodes the group as an object that keeps the order of its attributes
+func (g group) MarshalJSON() ([]byte, error) {
+	b := []byte{'{'}
+	for i, attribute := range g {
+		b = appendJSONField(b, attribute.Key, attribute.Value, i == 0)
+	}
+	return append(b, '}'), nil
+}
+
+// attributes bound to a logger with With, rendered ahead of time for each format
+type boundAttrs struct {
+	attrs      []Attr
+	plain      string
+	structured string
+	json       string
+}
+
+func renderBoundAttrs(attrs []Attr) boundAttrs {
+	var plain, structured, json []byte
+	for i, attribute := range attrs {
+		if i != 0 {
+			plain = append(plain, ", "...)
+			structured = append(structured, ' ')
+		}
+		plain = appendPlainAttr(plain, attribute)
+		structured = appendStructuredAttr(structured, attribute)
+		json = appendJSONField(json, attribute.Key, attribute.Value, false)
+	}
+	return boundAttrs{
+		attrs:      attrs,
+		plain:      string(plain),
+		structured: string(structured),
+		json:       string(json),
+	}
+}
+
+// With creates a child logger that includes the given attributes in every log
+// entry. The child starts with a copy of the logger's configuration so later
+// changes to either logger do not affect the other.
+func (l *Logger) With(attrs ...Attr) *Logger {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	child := &Logger{config: l.config}
+	if len(attrs) != 0 {
+		child.bound = renderBoundAttrs(
+			append(slices.Clip(l.bound.attrs), attrs...),
+		)
+	}
+	return child
+}
+
+// With creates a child of the default logger that includes the given attributes
+// in every log entry.
+func With(attrs ...Attr) *Logger {
+	return globalLogger.With(attrs...)
+}
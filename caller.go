@@ -0,0 +1,169 @@
+package timber
+
+import (
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// prefix of the function names of every frame inside of timber
+var packagePrefix = reflect.TypeOf(Logger{}).PkgPath() + "."
+
+type callerOptions struct {
+	enabled  bool
+	function bool
+	skip     int
+}
+
+type caller struct {
+	function string
+	path     string
+}
+
+// internalFrame checks if a frame is part of a logging call rather than the code
+// that made the call
+func internalFrame(function string) bool {
+	return strings.HasPrefix(function, packagePrefix)
+}
+
+// find the first frame outside of timber, skipping l.callers.skip extra frames
+func (l *Logger) caller() (caller, bool) {
+	pcs := make([]uintptr, 32+l.callers.skip)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	skip := l.callers.skip
+	for {
+		f, more := frames.Next()
+		if !internalFrame(f.Function) {
+			if skip == 0 {
+				return caller{
+					function: shortFunction(f.Function),
+					path:     shortPath(f.File) + ":" + strconv.Itoa(f.Line),
+				}, true
+			}
+			skip--
+		}
+		if !more {
+			return caller{}, false
+		}
+	}
+}
+
+// trim the import path from a function name so only the package name remains
+func shortFunction(function string) string {
+	return function[strings.LastIndexByte(function, '/')+1:]
+}
+
+// trim a file path down to the file and its parent directory
+func shortPath(file string) string {
+	i := strings.LastIndexByte(file, '/')
+	if i == -1 {
+		return file
+	}
+	j := strings.LastIndexByte(file[:i], '/')
+	if j == -1 {
+		return file
+	}
+	return file[j+1:]
+}
+
+// callerFromPC creates a caller from a program counter such as the one in a
+// slog.Record
+func callerFromPC(pc uintptr) (caller, bool) {
+	if pc == 0 {
+		return caller{}, false
+	}
+	f, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	if f.Function == "" {
+		return caller{}, false
+	}
+	return caller{
+		function: shortFunction(f.Function),
+		path:     shortPath(f.File) + ":" + strconv.Itoa(f.Line),
+	}, true
+}
+
+func (l *Logger) plainCaller(c caller, renderer *lipgloss.Renderer) string {
+	path := l.stackPathStyle.Renderer(renderer).Render("[" + c.path + "]")
+	if l.callers.function {
+		return c.function + " " + path
+	}
+	return path
+}
+
+func (l *Logger) structuredCaller(c caller) []Attr {
+	if l.callers.function {
+		return []Attr{{"caller", c.path}, {"function", c.function}}
+	}
+	return []Attr{{"caller", c.path}}
+}
+
+// Set if the file and line that made the log call should be shown.
+//
+// Default is false
+func (l *Logger) ShowCaller(show bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.callers.enabled = show
+}
+
+// Set if the function that made the log call should be shown along with the
+// file and line. Only applies when ShowCaller is enabled.
+//
+// Default is false
+func (l *Logger) ShowCallerFunction(show bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.callers.function = show
+}
+
+// Set the number of additional frames to skip when finding the caller. This
+// lets functions that wrap timber report the location of their own callers.
+//
+// Default is 0
+func (l *Logger) CallerSkip(skip int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.callers.skip = max(skip, 0)
+}
+
+// Set if the file and line that made the log call should be shown.
+//
+// Default is false
+func ShowCaller(show bool) {
+	globalLogger.ShowCaller(show)
+}
+
+// Set if the function that made the log call should be shown along with the
+// file and line. Only applies when ShowCaller is enabled.
+//
+// Default is false
+func ShowCallerFunction(show bool) {
+	globalLogger.ShowCallerFunction(show)
+}
+
+// Set the number of additional frames to skip when finding the caller. This
+// lets functions that wrap timber report the location of their own callers.
+//
+// Default is 0
+func CallerSkip(skip int) {
+	globalLogger.CallerSkip(skip)
+}
+
+// Option to set if the file and line that made the log call should be shown.
+func WithShowCaller(show bool) Option {
+	return func(l *Logger) { l.ShowCaller(show) }
+}
+
+// Option to set if the function that made the log call should be shown.
+func WithShowCallerFunction(show bool) Option {
+	return func(l *Logger) { l.ShowCallerFunction(show) }
+}
+
+// Option to set the number of additional frames to skip when finding the caller.
+func WithCallerSkip(skip int) Option {
+	return func(l *Logger) { l.CallerSkip(skip) }
+}
@@ -0,0 +1,152 @@
+package timber
+
+import (
+	"context"
+	"slices"
+)
+
+type contextKey struct{}
+
+// values stored in a context by NewContext and WithContext
+type contextValue struct {
+	logger *Logger
+	attrs  []Attr
+}
+
+func valueFromContext(ctx context.Context) contextValue {
+	if ctx == nil {
+		return contextValue{}
+	}
+	v, _ := ctx.Value(contextKey{}).(contextValue)
+	return v
+}
+
+// NewContext returns a copy of ctx that carries the given logger. The logger is
+// used by FromContext and the package-level *Context functions.
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	v := valueFromContext(ctx)
+	v.logger = l
+	return context.WithValue(ctx, contextKey{}, v)
+}
+
+// WithContext returns a copy of ctx that carries the given attributes in
+// addition to any attributes already in ctx. They are included in every log
+// entry made with one of the *Context functions.
+func WithContext(ctx context.Context, attrs ...Attr) context.Context {
+	v := valueFromContext(ctx)
+	v.attrs = append(slices.Clip(v.attrs), attrs...)
+	return context.WithValue(ctx, contextKey{}, v)
+}
+
+// FromContext returns the logger stored in ctx with the context's attributes
+// bound to it. If ctx doesn't carry a logger the default logger is used.
+func FromContext(ctx context.Context) *Logger {
+	v := valueFromContext(ctx)
+	l := v.logger
+	if l == nil {
+		l = globalLogger
+	}
+	if len(v.attrs) == 0 {
+		return l
+	}
+	return l.With(v.attrs...)
+}
+
+func contextLogger(ctx context.Context) *Logger {
+	if l := valueFromContext(ctx).logger; l != nil {
+		return l
+	}
+	return globalLogger
+}
+
+func contextAttrs(ctx context.Context, attrs []Attr) []Attr {
+	ctxAttrs := valueFromContext(ctx).attrs
+	if len(ctxAttrs) == 0 {
+		return attrs
+	}
+	return append(slices.Clip(ctxAttrs), attrs...)
+}
+
+// Output a DEBUG-level message with the attributes from ctx
+func (l *Logger) DebugContext(ctx context.Context, msg string, attrs ...Attr) {
+	l.Debug(msg, contextAttrs(ctx, attrs)...)
+}
+
+// Output a DONE-level message with the attributes from ctx
+func (l *Logger) DoneContext(ctx context.Context, msg string, attrs ...Attr) {
+	l.Done(msg, contextAttrs(ctx, attrs)...)
+}
+
+// Output a INFO-level message with the attributes from ctx
+func (l *Logger) InfoContext(ctx context.Context, msg string, attrs ...Attr) {
+	l.Info(msg, contextAttrs(ctx, attrs)...)
+}
+
+// Output a WARN-level message with the attributes from ctx
+func (l *Logger) WarningContext(ctx context.Context, msg string, attrs ...Attr) {
+	l.Warning(msg, contextAttrs(ctx, attrs)...)
+}
+
+// Output an ERROR-level message with information about the error and the
+// attributes from ctx
+func (l *Logger) ErrorContext(ctx context.Context, err error, msg string, attrs ...Attr) {
+	l.Error(err, msg, contextAttrs(ctx, attrs)...)
+}
+
+// Output an ERROR-level message with the attributes from ctx
+func (l *Logger) ErrorMsgContext(ctx context.Context, msg string, attrs ...Attr) {
+	l.ErrorMsg(msg, contextAttrs(ctx, attrs)...)
+}
+
+// Output a FATAL-level message with information about the error and the
+// attributes from ctx
+func (l *Logger) FatalContext(ctx context.Context, err error, msg string, attrs ...Attr) {
+	l.Fatal(err, msg, contextAttrs(ctx, attrs)...)
+}
+
+// Output a FATAL-level message with the attributes from ctx
+func (l *Logger) FatalMsgContext(ctx context.Context, msg string, attrs ...Attr) {
+	l.FatalMsg(msg, contextAttrs(ctx, attrs)...)
+}
+
+// Output a DEBUG-level message using the logger and attributes from ctx
+func DebugContext(ctx context.Context, msg string, attrs ...Attr) {
+	contextLogger(ctx).DebugContext(ctx, msg, attrs...)
+}
+
+// Output a DONE-level message using the logger and attributes from ctx
+func DoneContext(ctx context.Context, msg string, attrs ...Attr) {
+	contextLogger(ctx).DoneContext(ctx, msg, attrs...)
+}
+
+// Output a INFO-level message using the logger and attributes from ctx
+func InfoContext(ctx context.Context, msg string, attrs ...Attr) {
+	contextLogger(ctx).InfoContext(ctx, msg, attrs...)
+}
+
+// Output a WARN-level message using the logger and attributes from ctx
+func WarningContext(ctx context.Context, msg string, attrs ...Attr) {
+	contextLogger(ctx).WarningContext(ctx, msg, attrs...)
+}
+
+// Output an ERROR-level message with information about the error using the
+// logger and attributes from ctx
+func ErrorContext(ctx context.Context, err error, msg string, attrs ...Attr) {
+	contextLogger(ctx).ErrorContext(ctx, err, msg, attrs...)
+}
+
+// Output an ERROR-level message using the logger and attributes from ctx
+func ErrorMsgContext(ctx context.Context, msg string, attrs ...Attr) {
+	contextLogger(ctx).ErrorMsgContext(ctx, msg, attrs...)
+}
+
+// Output a FATAL-level message with information about the error using the
+// logger and attributes from ctx
+func FatalContext(ctx context.Context, err error, msg string, attrs ...Attr) {
+	contextLogger(ctx).FatalContext(ctx, err, msg, attrs...)
+}
+
+// Output a FATAL-level message using the logger and attributes from ctx
+func FatalMsgContext(ctx context.Context, msg string, attrs ...Attr) {
+	contextLogger(ctx).FatalMsgContext(ctx, msg, attrs...)
+}
@@ -2,44 +2,87 @@ package timber
 
 import (
 	"io"
-	"log"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
 )
 
-var globalLogger *logger
+var globalLogger = New()
 
-type logger struct {
-	mutex             sync.RWMutex
-	normalOutput      output
-	errOutput         output
+// Logger is an independently configured timber logger. The package-level
+// functions all operate on a default Logger which can be accessed with Default.
+type Logger struct {
+	mutex sync.RWMutex
+	config
+}
+
+type config struct {
+	sinks             []sink
+	routes            Routes
+	format            OutputFormat
 	fatalExitCode     int
+	fatalHooks        []func()
+	fatalTimeout      time.Duration
+	exitFunc          func(code int)
+	panicAction       PanicAction
 	showErrorStack    bool
 	showFatalStack    bool
 	displayTime       bool
 	durationFormatter func(time.Duration) string
 	timeFormat        string
 	timezone          *time.Location
-	levels            Levels
+	levels            map[Severity]definedLevel
+	minLevel          Severity
 	stackPathStyle    lipgloss.Style
+	stack             StackOptions
+	callers           callerOptions
+	timers            timerOptions
 	structured        structuredOptions
-}
-
-type output struct {
-	logger   *log.Logger
-	renderer *lipgloss.Renderer
-	writer   io.Writer
+	bound             boundAttrs
+	async             *asyncWriter
+	dropped           *atomic.Uint64 // shared with loggers created with With
 }
 
 type structuredOptions struct {
-	enabled    bool
-	timeFormat string
+	timeFormat   string
+	durationUnit DurationUnit
 }
 
-func init() {
+// DurationUnit is how durations from *Since functions are written in structured logs
+type DurationUnit int
+
+const (
+	// Text from the logger's duration formatter
+	DurationText DurationUnit = iota
+	// Integer number of nanoseconds
+	DurationNanoseconds
+	// Floating point number of seconds
+	DurationSeconds
+)
+
+// OutputFormat is the format that log entries are written in
+type OutputFormat int
+
+const (
+	// Human-readable logs with styled levels
+	Plain OutputFormat = iota
+	// Structured key=value logs
+	Logfmt
+	// Structured logs with one JSON object per line
+	JSON
+	// Human-readable logs without any color or styling
+	PlainNoColor
+)
+
+// Option configures a Logger created with New.
+type Option func(*Logger)
+
+// New creates a Logger with the default configuration and then applies the
+// given options to it.
+func New(opts ...Option) *Logger {
 	var (
 		out         = os.Stdout
 		errOut      = os.Stderr
@@ -47,165 +90,388 @@ func init() {
 		errRenderer = lipgloss.NewRenderer(errOut)
 		bold        = lipgloss.NewStyle().Bold(true)
 		errStyle    = errRenderer.NewStyle().Bold(true).Foreground(lipgloss.Color("#FF4747"))
-		l           = logger{
+		l           = &Logger{
 			mutex: sync.RWMutex{},
-			normalOutput: output{
-				logger:   log.New(out, "", 0),
-				writer:   out,
-				renderer: renderer,
-			},
-			errOutput: output{
-				logger:   log.New(errOut, "", 0),
-				writer:   errOut,
-				renderer: errRenderer,
-			},
-			fatalExitCode:     1,
-			showErrorStack:    true,
-			showFatalStack:    true,
-			stackPathStyle:    errRenderer.NewStyle().Foreground(lipgloss.Color("#6C6C6C")),
-			timeFormat:        "01/02/2006 15:04:05 MST",
-			timezone:          time.UTC,
-			displayTime:       true,
-			durationFormatter: formatDuration,
-			structured: structuredOptions{
-				enabled:    false,
-				timeFormat: time.RFC3339,
-			},
-			levels: Levels{
-				Debug: Level{
-					Message: "DEBUG",
-					Style: renderer.NewStyle().
-						Inherit(bold).
-						Foreground(lipgloss.Color("#2B95FF")),
-				},
-				Info: Level{
-					Message: "INFO",
-					Style:   bold,
+			config: config{
+				fatalExitCode:     1,
+				fatalTimeout:      5 * time.Second,
+				exitFunc:          os.Exit,
+				panicAction:       PanicRepanic,
+				showErrorStack:    true,
+				showFatalStack:    true,
+				stackPathStyle:    errRenderer.NewStyle().Foreground(lipgloss.Color("#6C6C6C")),
+				timeFormat:        "01/02/2006 15:04:05 MST",
+				timezone:          time.UTC,
+				displayTime:       true,
+				durationFormatter: formatDuration,
+				minLevel:          SeverityDebug,
+				dropped:           &atomic.Uint64{},
+				format:            Plain,
+				routes: Routes{
+					SeverityDebug:   {out},
+					SeverityInfo:    {out},
+					SeverityDone:    {out},
+					SeverityWarning: {out},
+					SeverityError:   {errOut},
+					SeverityFatal:   {errOut},
 				},
-				Done: Level{
-					Message: "DONE",
-					Style: renderer.NewStyle().
-						Inherit(bold).
-						Foreground(lipgloss.Color("#30CE75")),
-				},
-				Warning: Level{
-					Message: "WARN",
-					Style: renderer.NewStyle().
-						Inherit(bold).
-						Foreground(lipgloss.Color("#E1DC3F")),
-				},
-				Error: Level{
-					Message: "ERROR",
-					Style:   errStyle,
-				},
-				Fatal: Level{
-					Message: "FATAL",
-					Style:   errStyle,
+				structured: structuredOptions{
+					timeFormat:   time.RFC3339,
+					durationUnit: DurationText,
 				},
+				levels: levelRegistry(Levels{
+					Debug: Level{
+						Message: "DEBUG",
+						Style: renderer.NewStyle().
+							Inherit(bold).
+							Foreground(lipgloss.Color("#2B95FF")),
+					},
+					Info: Level{
+						Message: "INFO",
+						Style:   bold,
+					},
+					Done: Level{
+						Message: "DONE",
+						Style: renderer.NewStyle().
+							Inherit(bold).
+							Foreground(lipgloss.Color("#30CE75")),
+					},
+					Warning: Level{
+						Message: "WARN",
+						Style: renderer.NewStyle().
+							Inherit(bold).
+							Foreground(lipgloss.Color("#E1DC3F")),
+					},
+					Error: Level{
+						Message: "ERROR",
+						Style:   errStyle,
+					},
+					Fatal: Level{
+						Message: "FATAL",
+						Style:   errStyle,
+					},
+				}),
 			},
 		}
 	)
-	renderLevels(&l, true, true)
-	globalLogger = &l
+	l.routeSinks()
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
 }
 
-// Set the output for Debug, Done, Warning, and Info.
+// Get the default logger used by the package-level functions.
+func Default() *Logger {
+	return globalLogger
+}
+
+// Set the output for Debug, Done, Warning, and Info. Those levels are routed to
+// only this writer.
+//
+// Default is os.Stdout
+func (l *Logger) Out(writer io.Writer) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	for _, severity := range []Severity{SeverityDebug, SeverityInfo, SeverityDone, SeverityWarning} {
+		l.route(severity, writer)
+	}
+	l.routeSinks()
+}
+
+// Set the output for Fatal, FatalMsg, Error, and ErrorMsg. Those levels are
+// routed to only this writer.
+//
+// Default is os.Stderr
+func (l *Logger) ErrOut(writer io.Writer) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	for _, severity := range []Severity{SeverityError, SeverityFatal} {
+		l.route(severity, writer)
+	}
+	l.routeSinks()
+}
+
+// Set the exit code used by Fatal and FatalMsg.
+//
+// Default is 1
+func (l *Logger) FatalExitCode(code int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.fatalExitCode = code
+}
+
+// Set if the stack trace should be shown or not when calling Error.
+//
+// Default is true
+func (l *Logger) ShowErrorStack(show bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.showErrorStack = show
+}
+
+// Set the style of the path for a stack trace.
+//
+// Default is #6C6C6C
+func (l *Logger) StackPathStyle(style lipgloss.Style) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.stackPathStyle = style
+}
+
+// Set if the stack trace should be shown or not when calling Fatal.
+//
+// Default is true
+func (l *Logger) ShowFatalStack(show bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.showFatalStack = show
+}
+
+// Set the function used to format durations for *Since methods. Passing nil
+// restores the default formatter.
+func (l *Logger) DurationFormatter(fn func(time.Duration) string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if fn == nil {
+		fn = formatDuration
+	}
+	l.durationFormatter = fn
+}
+
+// Set how durations are written in structured logs.
+//
+// Default is DurationText
+func (l *Logger) StructuredDuration(unit DurationUnit) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.structured.durationUnit = unit
+}
+
+// Set if the time should be shown at all or not.
+//
+// Default is true
+func (l *Logger) DisplayTime(display bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.displayTime = display
+}
+
+// Set the time format that time stamps are formatted with.
+//
+// Default is 01/02/2006 15:04:05 MST
+func (l *Logger) TimeFormat(format string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.timeFormat = format
+}
+
+// Set the timezone that time stamps are logged in.
+//
+// Default is time.UTC
+func (l *Logger) Timezone(loc *time.Location) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.timezone = loc
+}
+
+// Set if the logs should be structured. This is the same as setting the
+// format to Logfmt when enabled and Plain when disabled.
+//
+// Default is false
+func (l *Logger) Structured(enabled bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	format := Plain
+	if enabled {
+		format = Logfmt
+	}
+	l.setFormat(format)
+}
+
+// Set the format that logs are written in by the writers in the routing table.
+//
+// Default is Plain
+func (l *Logger) Format(format OutputFormat) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.setFormat(format)
+}
+
+func (l *Logger) setFormat(format OutputFormat) {
+	l.format = format
+	l.routeSinks()
+}
+
+// Set the output for Debug, Done, Warning, and Info. Those levels are routed to
+// only this writer.
 //
 // Default is os.Stdout
 func Out(writer io.Writer) {
-	globalLogger.mutex.Lock()
-	defer globalLogger.mutex.Unlock()
-	globalLogger.normalOutput.writer = writer
-	globalLogger.normalOutput.renderer = lipgloss.NewRenderer(writer)
-	renderLevels(globalLogger, true, false)
+	globalLogger.Out(writer)
 }
 
-// Set the output for Fatal, FatalMsg, Error, and ErrorMsg.
+// Set the output for Fatal, FatalMsg, Error, and ErrorMsg. Those levels are
+// routed to only this writer.
 //
 // Default is os.Stderr
 func ErrOut(writer io.Writer) {
-	globalLogger.mutex.Lock()
-	defer globalLogger.mutex.Unlock()
-	globalLogger.errOutput.writer = writer
-	globalLogger.errOutput.renderer = lipgloss.NewRenderer(writer)
-	renderLevels(globalLogger, false, true)
+	globalLogger.ErrOut(writer)
 }
 
 // Set the exit code used by Fatal and FatalMsg.
 //
 // Default is 1
 func FatalExitCode(code int) {
-	globalLogger.mutex.Lock()
-	defer globalLogger.mutex.Unlock()
-	globalLogger.fatalExitCode = code
+	globalLogger.FatalExitCode(code)
 }
 
 // Set if the stack trace should be shown or not when calling Error.
 //
 // Default is true
 func ShowErrorStack(show bool) {
-	globalLogger.mutex.Lock()
-	defer globalLogger.mutex.Unlock()
-	globalLogger.showErrorStack = show
+	globalLogger.ShowErrorStack(show)
 }
 
 // Set the style of the path for a stack trace.
 //
 // Default is #6C6C6C
 func StackPathStyle(style lipgloss.Style) {
-	globalLogger.mutex.Lock()
-	defer globalLogger.mutex.Unlock()
-	globalLogger.stackPathStyle = style
+	globalLogger.StackPathStyle(style)
 }
 
 // Set if the stack trace should be shown or not when calling Fatal.
 //
 // Default is true
 func ShowFatalStack(show bool) {
-	globalLogger.mutex.Lock()
-	defer globalLogger.mutex.Unlock()
-	globalLogger.showFatalStack = show
+	globalLogger.ShowFatalStack(show)
 }
 
 // Set the function used to format durations for timber.*Since functions.
+// Passing nil restores the default formatter.
 func DurationFormatter(fn func(time.Duration) string) {
-	globalLogger.mutex.Lock()
-	defer globalLogger.mutex.Unlock()
-	globalLogger.durationFormatter = fn
+	globalLogger.DurationFormatter(fn)
+}
+
+// Set how durations are written in structured logs.
+//
+// Default is DurationText
+func StructuredDuration(unit DurationUnit) {
+	globalLogger.StructuredDuration(unit)
 }
 
 // Set if the time should be shown at all or not.
 //
 // Default is true
 func DisplayTime(display bool) {
-	globalLogger.mutex.Lock()
-	defer globalLogger.mutex.Unlock()
-	globalLogger.displayTime = display
+	globalLogger.DisplayTime(display)
 }
 
 // Set the time format that time stamps are formatted with.
 //
 // Default is 01/02/2006 15:04:05 MST
 func TimeFormat(format string) {
-	globalLogger.mutex.Lock()
-	defer globalLogger.mutex.Unlock()
-	globalLogger.timeFormat = format
+	globalLogger.TimeFormat(format)
 }
 
 // Set the timezone that time stamps are logged in.
 //
 // Default is time.UTC
 func Timezone(loc *time.Location) {
-	globalLogger.mutex.Lock()
-	defer globalLogger.mutex.Unlock()
-	globalLogger.timezone = loc
+	globalLogger.Timezone(loc)
 }
 
-// Set if the logs should be structured
+// Set if the logs should be structured. This is the same as setting the
+// format to Logfmt when enabled and Plain when disabled.
 //
 // Default is false
 func Structured(enabled bool) {
-	globalLogger.mutex.Lock()
-	defer globalLogger.mutex.Unlock()
-	globalLogger.structured.enabled = enabled
+	globalLogger.Structured(enabled)
+}
+
+// Set the format that logs are written in by the writers in the routing table.
+//
+// Default is Plain
+func Format(format OutputFormat) {
+	globalLogger.Format(format)
+}
+
+// Option to set the output for Debug, Done, Warning, and Info.
+func WithOut(writer io.Writer) Option {
+	return func(l *Logger) { l.Out(writer) }
+}
+
+// Option to set the output for Fatal, FatalMsg, Error, and ErrorMsg.
+func WithErrOut(writer io.Writer) Option {
+	return func(l *Logger) { l.ErrOut(writer) }
+}
+
+// Option to set the exit code used by Fatal and FatalMsg.
+func WithFatalExitCode(code int) Option {
+	return func(l *Logger) { l.FatalExitCode(code) }
+}
+
+// Option to set if the stack trace should be shown or not when calling Error.
+func WithShowErrorStack(show bool) Option {
+	return func(l *Logger) { l.ShowErrorStack(show) }
+}
+
+// Option to set if the stack trace should be shown or not when calling Fatal.
+func WithShowFatalStack(show bool) Option {
+	return func(l *Logger) { l.ShowFatalStack(show) }
+}
+
+// Option to set the style of the path for a stack trace.
+func WithStackPathStyle(style lipgloss.Style) Option {
+	return func(l *Logger) { l.StackPathStyle(style) }
+}
+
+// Option to set the function used to format durations for *Since methods.
+func WithDurationFormatter(fn func(time.Duration) string) Option {
+	return func(l *Logger) { l.DurationFormatter(fn) }
+}
+
+// Option to set how durations are written in structured logs.
+func WithStructuredDuration(unit DurationUnit) Option {
+	return func(l *Logger) { l.StructuredDuration(unit) }
+}
+
+// Option to set if the time should be shown at all or not.
+func WithDisplayTime(display bool) Option {
+	return func(l *Logger) { l.DisplayTime(display) }
+}
+
+// Option to set the time format that time stamps are formatted with.
+func WithTimeFormat(format string) Option {
+	return func(l *Logger) { l.TimeFormat(format) }
+}
+
+// Option to set the timezone that time stamps are logged in.
+func WithTimezone(loc *time.Location) Option {
+	return func(l *Logger) { l.Timezone(loc) }
+}
+
+// Option to set if the logs should be structured.
+func WithStructured(enabled bool) Option {
+	return func(l *Logger) { l.Structured(enabled) }
+}
+
+// Option to set the format that logs are written in.
+func WithFormat(format OutputFormat) Option {
+	return func(l *Logger) { l.Format(format) }
+}
+
+// Option to define a custom level or redefine an existing one.
+func WithDefinedLevel(def LevelDefinition) Option {
+	return func(l *Logger) { l.DefineLevel(def) }
+}
+
+// Option to set the levels that the logger logs at.
+func WithLevels(levels Levels) Option {
+	return func(l *Logger) { l.SetLevels(levels) }
+}
+
+// Option to set the minimum severity that will be logged.
+func WithMinLevel(severity Severity) Option {
+	return func(l *Logger) { l.SetMinLevel(severity) }
 }
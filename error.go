@@ -1,92 +1,85 @@
 package timber
 
-import (
-	"os"
-	"time"
-)
+import "time"
+
+// Output an ERROR-level message with information about the error
+func (l *Logger) Error(err error, msg string, attrs ...Attr) {
+	l.log(SeverityError, time.Time{}, err, msg, attrs)
+}
+
+// Output an ERROR-level message since a certain time with information about the error
+func (l *Logger) ErrorSince(err error, start time.Time, msg string, attrs ...Attr) {
+	l.log(SeverityError, start, err, msg, attrs)
+}
+
+// Output an ERROR-level message
+func (l *Logger) ErrorMsg(msg string, attrs ...Attr) {
+	l.log(SeverityError, time.Time{}, nil, msg, attrs)
+}
+
+// Output an ERROR-level message since a certain time
+func (l *Logger) ErrorMsgSince(start time.Time, msg string, attrs ...Attr) {
+	l.log(SeverityError, start, nil, msg, attrs)
+}
+
+// Output a FATAL-level message with information about the error and then exit
+func (l *Logger) Fatal(err error, msg string, attrs ...Attr) {
+	l.log(SeverityFatal, time.Time{}, err, msg, attrs)
+}
+
+// Output a FATAL-level message since a certain time with information about the
+// error and then exit
+func (l *Logger) FatalSince(err error, start time.Time, msg string, attrs ...Attr) {
+	l.log(SeverityFatal, start, err, msg, attrs)
+}
+
+// Output a FATAL-level message and then exit
+func (l *Logger) FatalMsg(msg string, attrs ...Attr) {
+	l.log(SeverityFatal, time.Time{}, nil, msg, attrs)
+}
+
+// Output a FATAL-level message since a certain time and then exit
+func (l *Logger) FatalMsgSince(start time.Time, msg string, attrs ...Attr) {
+	l.log(SeverityFatal, start, nil, msg, attrs)
+}
 
 // Output an ERROR-level message with information about the error
 func Error(err error, msg string, attrs ...Attr) {
-	globalLogger.mutex.RLock()
-	defer globalLogger.mutex.RUnlock()
-	logError(globalLogger.levels.Error, err, msg, attrs, globalLogger.showErrorStack)
+	globalLogger.Error(err, msg, attrs...)
 }
 
 // Output an ERROR-level message since a certain time with information about the error
 func ErrorSince(err error, start time.Time, msg string, attrs ...Attr) {
-	globalLogger.mutex.RLock()
-	defer globalLogger.mutex.RUnlock()
-	logDurationError(
-		globalLogger.levels.Error,
-		err,
-		start,
-		msg,
-		attrs,
-		globalLogger.showErrorStack,
-	)
-}
-
-// Output a ERROR-level message
+	globalLogger.ErrorSince(err, start, msg, attrs...)
+}
+
+// Output an ERROR-level message
 func ErrorMsg(msg string, attrs ...Attr) {
-	globalLogger.mutex.RLock()
-	defer globalLogger.mutex.RUnlock()
-	logError(globalLogger.levels.Error, nil, msg, attrs, globalLogger.showErrorStack)
+	globalLogger.ErrorMsg(msg, attrs...)
 }
 
 // Output an ERROR-level message since a certain time
 func ErrorMsgSince(start time.Time, msg string, attrs ...Attr) {
-	globalLogger.mutex.RLock()
-	defer globalLogger.mutex.RUnlock()
-	logDurationError(
-		globalLogger.levels.Error,
-		nil,
-		start,
-		msg,
-		attrs,
-		globalLogger.showErrorStack,
-	)
-}
-
-// Output a FATAL-level message with information about the error
+	globalLogger.ErrorMsgSince(start, msg, attrs...)
+}
+
+// Output a FATAL-level message with information about the error and then exit
 func Fatal(err error, msg string, attrs ...Attr) {
-	globalLogger.mutex.RLock()
-	defer globalLogger.mutex.RUnlock()
-	logError(globalLogger.levels.Fatal, err, msg, attrs, globalLogger.showFatalStack)
-	os.Exit(globalLogger.fatalExitCode)
+	globalLogger.Fatal(err, msg, attrs...)
 }
 
-// Output an FATAL log message since a certain time with information about the error
+// Output a FATAL-level message since a certain time with information about the
+// error and then exit
 func FatalSince(err error, start time.Time, msg string, attrs ...Attr) {
-	globalLogger.mutex.RLock()
-	defer globalLogger.mutex.RUnlock()
-	logDurationError(
-		globalLogger.levels.Fatal,
-		err,
-		start,
-		msg,
-		attrs,
-		globalLogger.showErrorStack,
-	)
-}
-
-// Output a FATAL-level message
+	globalLogger.FatalSince(err, start, msg, attrs...)
+}
+
+// Output a FATAL-level message and then exit
 func FatalMsg(msg string, attrs ...Attr) {
-	globalLogger.mutex.RLock()
-	defer globalLogger.mutex.RUnlock()
-	logError(globalLogger.levels.Fatal, nil, msg, attrs, globalLogger.showFatalStack)
-	os.Exit(globalLogger.fatalExitCode)
-}
-
-// Output an FATAL-level message since a certain time
-func FatalMsgSince(err error, start time.Time, msg string, attrs ...Attr) {
-	globalLogger.mutex.RLock()
-	defer globalLogger.mutex.RUnlock()
-	logDurationError(
-		globalLogger.levels.Fatal,
-		err,
-		start,
-		msg,
-		attrs,
-		globalLogger.showErrorStack,
-	)
+	globalLogger.FatalMsg(msg, attrs...)
+}
+
+// Output a FATAL-level message since a certain time and then exit
+func FatalMsgSince(start time.Time, msg string, attrs ...Attr) {
+	globalLogger.FatalMsgSince(start, msg, attrs...)
 }
@@ -0,0 +1,186 @@
+package timber
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maximum depth of an error chain that will be walked
+const maxErrorDepth = 32
+
+// unwrapErrors returns the errors directly wrapped by err and if err joins
+// multiple errors together
+func unwrapErrors(err error) (causes []error, joined bool) {
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, wrapped := range e.Unwrap() {
+			if wrapped != nil {
+				causes = append(causes, wrapped)
+			}
+		}
+		return causes, true
+	case interface{ Unwrap() error }:
+		if wrapped := e.Unwrap(); wrapped != nil {
+			return []error{wrapped}, false
+		}
+	}
+	return nil, false
+}
+
+// walkErrors calls fn for err and every error that it wraps, depth first
+func walkErrors(err error, depth int, fn func(err error, depth int)) {
+	if err == nil || depth > maxErrorDepth {
+		return
+	}
+	fn(err, depth)
+	causes, _ := unwrapErrors(err)
+	for _, cause := range causes {
+		walkErrors(cause, depth+1, fn)
+	}
+}
+
+// errorCauses returns the message of every error wrapped by err, depth first
+func errorCauses(err error) []string {
+	var causes []string
+	walkErrors(err, 0, func(e error, depth int) {
+		if depth != 0 {
+			causes = append(causes, e.Error())
+		}
+	})
+	return causes
+}
+
+// errorAttrs collects the attributes of every error in the chain that has a
+// LogAttrs() []timber.Attr method
+func errorAttrs(err error) []Attr {
+	var attrs []Attr
+	walkErrors(err, 0, func(e error, _ int) {
+		if withAttrs, ok := e.(interface{ LogAttrs() []Attr }); ok {
+			attrs = append(attrs, withAttrs.LogAttrs()...)
+		}
+	})
+	return attrs
+}
+
+// ownMessage returns the part of an error's message that isn't just the message
+// of the errors that it wraps
+func ownMessage(err error, causes []error, joined bool) string {
+	msg := err.Error()
+	if joined {
+		causeMsgs := make([]string, 0, len(causes))
+		for _, cause := range causes {
+			causeMsgs = append(causeMsgs, cause.Error())
+		}
+		if msg == strings.Join(causeMsgs, "\n") {
+			return ""
+		}
+		return msg
+	}
+	if len(causes) == 1 {
+		if own, ok := strings.CutSuffix(msg, causes[0].Error()); ok {
+			return strings.TrimRight(own, ": \t\n")
+		}
+	}
+	return msg
+}
+
+// errorLines renders err as lines where joined errors are a bulleted list and
+// wrapped errors are an indented list of causes. list is true if the lines are
+// a bulleted list without a message of their own.
+func errorLines(style lipgloss.Style, err error, depth int) (lines []string, list bool) {
+	causes, joined := unwrapErrors(err)
+	if depth >= maxErrorDepth {
+		causes = nil
+	}
+	own := ownMessage(err, causes, joined)
+	if own == "" && !joined && len(causes) == 1 {
+		// the error only wraps another error without adding anything to it
+		return errorLines(style, causes[0], depth+1)
+	}
+	if own != "" {
+		lines = strings.Split(own, "\n")
+	}
+
+	// causes line up with the start of the message that they belong to while
+	// lists are indented beneath it
+	indent := ""
+	if joined && own != "" {
+		indent = "  "
+	}
+	marker := style.Render("↳")
+	if joined {
+		marker = style.Render("•")
+	}
+	for _, cause := range causes {
+		causeLines, causeList := errorLines(style, cause, depth+1)
+		for i, line := range causeLines {
+			switch {
+			case causeList && !joined:
+				lines = append(lines, "  "+line)
+			case i == 0:
+				lines = append(lines, indent+marker+" "+line)
+			default:
+				lines = append(lines, indent+"  "+line)
+			}
+		}
+	}
+	return lines, joined && own == ""
+}
+
+func plainError(style lipgloss.Style, err error) string {
+	lines, _ := errorLines(style, err, 0)
+	return strings.Join(lines, "\n")
+}
+
+// stackFromError returns the stack carried by the most deeply wrapped error in
+// the chain that has one
+func stackFromError(err error) []Frame {
+	var (
+		stack     []Frame
+		bestDepth = -1
+	)
+	walkErrors(err, 0, func(e error, depth int) {
+		if depth <= bestDepth {
+			return
+		}
+		if frames := framesFromError(e); len(frames) != 0 {
+			stack = frames
+			bestDepth = depth
+		}
+	})
+	return stack
+}
+
+// framesFromError gets the stack from an error that carries one. Errors can
+// provide their stack with a Frames() []timber.Frame method, a Callers()
+// []uintptr method, or a StackTrace() method that returns a slice of program
+// counters like github.com/pkg/errors.
+func framesFromError(err error) []Frame {
+	switch e := err.(type) {
+	case interface{ Frames() []Frame }:
+		return e.Frames()
+	case interface{ Callers() []uintptr }:
+		return framesFromPCs(e.Callers())
+	}
+
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() {
+		return nil
+	}
+	methodType := method.Type()
+	if methodType.NumIn() != 0 || methodType.NumOut() != 1 {
+		return nil
+	}
+	out := methodType.Out(0)
+	if out.Kind() != reflect.Slice || out.Elem().Kind() != reflect.Uintptr {
+		return nil
+	}
+	trace := method.Call(nil)[0]
+	pcs := make([]uintptr, trace.Len())
+	for i := range pcs {
+		pcs[i] = uintptr(trace.Index(i).Uint())
+	}
+	return framesFromPCs(pcs)
+}
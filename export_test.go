@@ -0,0 +1,9 @@
+package timber
+
+// SetDefault replaces the default logger used by the package-level functions and
+// returns a function that restores it
+func SetDefault(l *Logger) (restore func()) {
+	previous := globalLogger
+	globalLogger = l
+	return func() { globalLogger = previous }
+}
@@ -0,0 +1,125 @@
+package timber
+
+import (
+	"io"
+	"os"
+	"slices"
+	"time"
+)
+
+// flushWriter flushes any data that a writer has buffered
+func flushWriter(w io.Writer) {
+	switch f := w.(type) {
+	case interface{ Flush() error }:
+		_ = f.Flush()
+	case interface{ Sync() error }:
+		_ = f.Sync()
+	}
+}
+
+// exit runs the fatal hooks, writes buffered entries, flushes the outputs, and
+// then exits the program with the fatal exit code. Hooks and flushing are given
+// up on if they take longer than the fatal timeout.
+func (l *Logger) exit() {
+	l.mutex.RLock()
+	var (
+		hooks    = slices.Clone(l.fatalHooks)
+		async    = l.async
+		writers  = l.writers()
+		timeout  = l.fatalTimeout
+		exitFunc = l.exitFunc
+		code     = l.fatalExitCode
+	)
+	l.mutex.RUnlock()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, hook := range hooks {
+			hook()
+		}
+		if async != nil {
+			async.wait()
+		}
+		for _, w := range writers {
+			flushWriter(w)
+		}
+	}()
+	if timeout > 0 {
+		select {
+		case <-done:
+		case <-time.After(timeout):
+		}
+	} else {
+		<-done
+	}
+	exitFunc(code)
+}
+
+// Register a function to run before a fatal log exits the program. Hooks run in
+// the order they were registered.
+func (l *Logger) OnFatal(fn func()) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.fatalHooks = append(slices.Clip(l.fatalHooks), fn)
+}
+
+// Set the maximum amount of time that fatal hooks and flushing the outputs can
+// take before the program exits anyway. 0 waits forever.
+//
+// Default is 5 seconds
+func (l *Logger) FatalTimeout(timeout time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.fatalTimeout = timeout
+}
+
+// Set the function used to exit the program after a fatal log. Passing nil
+// restores os.Exit.
+//
+// Default is os.Exit
+func (l *Logger) ExitFunc(fn func(code int)) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if fn == nil {
+		fn = os.Exit
+	}
+	l.exitFunc = fn
+}
+
+// Register a function to run before a fatal log exits the program. Hooks run in
+// the order they were registered.
+func OnFatal(fn func()) {
+	globalLogger.OnFatal(fn)
+}
+
+// Set the maximum amount of time that fatal hooks and flushing the outputs can
+// take before the program exits anyway. 0 waits forever.
+//
+// Default is 5 seconds
+func FatalTimeout(timeout time.Duration) {
+	globalLogger.FatalTimeout(timeout)
+}
+
+// Set the function used to exit the program after a fatal log. Passing nil
+// restores os.Exit.
+//
+// Default is os.Exit
+func ExitFunc(fn func(code int)) {
+	globalLogger.ExitFunc(fn)
+}
+
+// Option to register a function to run before a fatal log exits the program.
+func WithOnFatal(fn func()) Option {
+	return func(l *Logger) { l.OnFatal(fn) }
+}
+
+// Option to set the maximum amount of time that fatal hooks and flushing can take.
+func WithFatalTimeout(timeout time.Duration) Option {
+	return func(l *Logger) { l.FatalTimeout(timeout) }
+}
+
+// Option to set the function used to exit the program after a fatal log.
+func WithExitFunc(fn func(code int)) Option {
+	return func(l *Logger) { l.ExitFunc(fn) }
+}
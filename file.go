@@ -0,0 +1,346 @@
+package timber
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// time format used in the names of rotated files
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// FileOptions configures a file sink
+type FileOptions struct {
+	// Rotate the file once writing to it would make it larger than this many
+	// bytes. 0 never rotates based on size.
+	MaxSize int64
+	// Rotate the file once it has been written to for this long, counting from
+	// when it was last rotated or created even if the program has restarted
+	// since. On file systems that don't record when files were created, a file
+	// that has never been rotated counts from when it was last written to. 0
+	// never rotates based on age.
+	MaxAge time.Duration
+	// Number of rotated files to keep. 0 keeps every rotated file.
+	MaxBackups int
+	// Compress rotated files with gzip
+	Compress bool
+	// Reopen the file when the program receives SIGHUP so that it works with
+	// external tools like logrotate
+	ReopenOnSIGHUP bool
+	// Minimum and maximum severity written to the file. 0 has no limit.
+	MinLevel Severity
+	MaxLevel Severity
+	// Format the file is written in. Plain is written without color.
+	Format OutputFormat
+}
+
+// RotatingFile is a file that is rotated by size and age. It is the writer of
+// sinks created with FileSink.
+type RotatingFile struct {
+	path string
+	opts FileOptions
+
+	mutex    sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	closed   bool
+
+	// compressing and removing old files happens in the background
+	mill    sync.Mutex
+	milling sync.WaitGroup
+
+	signals     chan os.Signal
+	done        chan struct{}
+	stopSignals sync.Once
+}
+
+// FileSink creates a sink that writes to the file at path, rotating it based on
+// the options. The sink's writer is a *RotatingFile which should be closed once
+// nothing else will be logged to it.
+func FileSink(path string, opts FileOptions) (Sink, error) {
+	f, err := OpenRotatingFile(path, opts)
+	if err != nil {
+		return Sink{}, err
+	}
+	format := opts.Format
+	if format == Plain {
+		format = PlainNoColor
+	}
+	return Sink{Writer: f, MinLevel: opts.MinLevel, MaxLevel: opts.MaxLevel, Format: format}, nil
+}
+
+// OpenRotatingFile opens the file at path for appending, creating it and its
+// directory if they don't exist
+func OpenRotatingFile(path string, opts FileOptions) (*RotatingFile, error) {
+	f := &RotatingFile{path: path, opts: opts}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	if opts.ReopenOnSIGHUP {
+		f.signals = make(chan os.Signal, 1)
+		f.done = make(chan struct{})
+		signal.Notify(f.signals, syscall.SIGHUP)
+		go f.watchSignals(f.signals, f.done)
+	}
+	return f, nil
+}
+
+func (f *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = time.Now()
+	if f.size > 0 {
+		f.openedAt = f.startedAt(info)
+	}
+	return nil
+}
+
+// startedAt gets when an existing file started being written to so that its age
+// isn't reset by reopening it. That is when the newest backup was rotated, or
+// when the file was created if it has never been rotated. The backup is checked
+// first since Windows can give a new file the creation time of the file that
+// was just renamed away from its name.
+func (f *RotatingFile) startedAt(info os.FileInfo) time.Time {
+	if backups := f.backups(); len(backups) != 0 {
+		if t, ok := f.backupTime(filepath.Base(backups[len(backups)-1])); ok {
+			return t
+		}
+	}
+	if t, ok := fileCreated(f.path, info); ok {
+		return t
+	}
+	return info.ModTime()
+}
+
+func (f *RotatingFile) watchSignals(signals <-chan os.Signal, done <-chan struct{}) {
+	for {
+		select {
+		case <-signals:
+			_ = f.Reopen()
+		case <-done:
+			return
+		}
+	}
+}
+
+// Write writes p to the file, rotating it first if needed
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.closed {
+		return 0, os.ErrClosed
+	}
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return 0, err
+		}
+	}
+	if f.shouldRotate(len(p)) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+func (f *RotatingFile) shouldRotate(size int) bool {
+	if f.opts.MaxSize > 0 && f.size > 0 && f.size+int64(size) > f.opts.MaxSize {
+		return true
+	}
+	return f.opts.MaxAge > 0 && time.Since(f.openedAt) >= f.opts.MaxAge
+}
+
+// Rotate moves the current file to a backup and starts a new file
+func (f *RotatingFile) Rotate() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.closed {
+		return os.ErrClosed
+	}
+	return f.rotate()
+}
+
+func (f *RotatingFile) rotate() error {
+	if f.file != nil {
+		if err := f.file.Close(); err != nil {
+			return err
+		}
+		f.file = nil
+	}
+	backup := f.backupName(time.Now())
+	if err := os.Rename(f.path, backup); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	f.milling.Add(1)
+	go f.millBackups(backup)
+	return nil
+}
+
+// backupName gets an unused name for a rotated file. Rotating more than once in
+// the same millisecond moves the time forward so earlier backups aren't replaced.
+func (f *RotatingFile) backupName(t time.Time) string {
+	ext := filepath.Ext(f.path)
+	for {
+		name := strings.TrimSuffix(f.path, ext) + "-" + t.Format(backupTimeFormat) + ext
+		if !fileExists(name) && !fileExists(name+".gz") {
+			return name
+		}
+		t = t.Add(time.Millisecond)
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// Reopen closes and reopens the file, for when it has been moved by another
+// program
+func (f *RotatingFile) Reopen() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.closed {
+		return os.ErrClosed
+	}
+	if f.file != nil {
+		if err := f.file.Close(); err != nil {
+			return err
+		}
+		f.file = nil
+	}
+	return f.open()
+}
+
+// Sync commits the file's contents to storage
+func (f *RotatingFile) Sync() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	return f.file.Sync()
+}
+
+// Close closes the file and waits for rotated files to finish being compressed
+func (f *RotatingFile) Close() error {
+	if f.done != nil {
+		f.stopSignals.Do(func() {
+			signal.Stop(f.signals)
+			close(f.done)
+		})
+	}
+	f.mutex.Lock()
+	f.closed = true
+	var err error
+	if f.file != nil {
+		err = f.file.Close()
+		f.file = nil
+	}
+	f.mutex.Unlock()
+	f.milling.Wait()
+	return err
+}
+
+// millBackups compresses a newly rotated file and removes the oldest backups
+func (f *RotatingFile) millBackups(backup string) {
+	defer f.milling.Done()
+	f.mill.Lock()
+	defer f.mill.Unlock()
+	if f.opts.Compress {
+		_ = compressFile(backup)
+	}
+	if f.opts.MaxBackups > 0 {
+		backups := f.backups()
+		for len(backups) > f.opts.MaxBackups {
+			_ = os.Remove(backups[0])
+			backups = backups[1:]
+		}
+	}
+}
+
+// backups gets the rotated files from oldest to newest
+func (f *RotatingFile) backups() []string {
+	entries, err := os.ReadDir(filepath.Dir(f.path))
+	if err != nil {
+		return nil
+	}
+	var backups []string
+	for _, entry := range entries {
+		if _, ok := f.backupTime(entry.Name()); entry.IsDir() || !ok {
+			continue
+		}
+		backups = append(backups, filepath.Join(filepath.Dir(f.path), entry.Name()))
+	}
+	// the time stamps sort in the order that the files were rotated
+	slices.SortFunc(backups, func(a, b string) int {
+		return strings.Compare(strings.TrimSuffix(a, ".gz"), strings.TrimSuffix(b, ".gz"))
+	})
+	return backups
+}
+
+// backupTime gets the time that a rotated file was rotated at from its name
+func (f *RotatingFile) backupTime(name string) (time.Time, bool) {
+	var (
+		ext    = filepath.Ext(f.path)
+		prefix = filepath.Base(strings.TrimSuffix(f.path, ext)) + "-"
+	)
+	stamp, ok := strings.CutPrefix(strings.TrimSuffix(name, ".gz"), prefix)
+	if !ok {
+		return time.Time{}, false
+	}
+	stamp, ok = strings.CutSuffix(stamp, ext)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation(backupTimeFormat, stamp, time.Local)
+	return t, err == nil
+}
+
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(dst)
+	if _, err = io.Copy(gz, src); err == nil {
+		err = gz.Close()
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path + ".gz")
+		return err
+	}
+	return os.Remove(path)
+}
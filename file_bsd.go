@@ -0,0 +1,18 @@
+//go:build darwin || freebsd || netbsd
+
+package timber
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// fileCreated gets when a file was created if the file system records it
+func fileCreated(_ string, info os.FileInfo) (time.Time, bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(st.Birthtimespec.Unix()), true
+}
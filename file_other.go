@@ -0,0 +1,13 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !windows
+
+package timber
+
+import (
+	"os"
+	"time"
+)
+
+// fileCreated gets when a file was created, which isn't available on this system
+func fileCreated(string, os.FileInfo) (time.Time, bool) {
+	return time.Time{}, false
+}
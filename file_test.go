@@ -0,0 +1,203 @@
+package timber
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRotatingFileMaxSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	f, err := OpenRotatingFile(path, FileOptions{MaxSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"first\n", "second\n", "third\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	backups := f.backups()
+	if len(backups) != 2 {
+		t.Fatalf("backups = %v, want 2", backups)
+	}
+	for i, want := range []string{"first\n", "second\n", "third\n"} {
+		file := path
+		if i < len(backups) {
+			file = backups[i]
+		}
+		if got := readFile(t, file); got != want {
+			t.Errorf("%s = %q, want %q", filepath.Base(file), got, want)
+		}
+	}
+}
+
+func TestRotatingFileMaxBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	f, err := OpenRotatingFile(path, FileOptions{MaxBackups: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 5 {
+		if _, err := f.Write([]byte{'0' + byte(i)}); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.Rotate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	backups := f.backups()
+	if len(backups) != 2 {
+		t.Fatalf("backups = %v, want 2", backups)
+	}
+	// only the newest backups are kept
+	for i, want := range []string{"3", "4"} {
+		if got := readFile(t, backups[i]); got != want {
+			t.Errorf("%s = %q, want %q", filepath.Base(backups[i]), got, want)
+		}
+	}
+}
+
+func TestRotatingFileCompress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	f, err := OpenRotatingFile(path, FileOptions{Compress: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("compressed\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	backups := f.backups()
+	if len(backups) != 1 || !strings.HasSuffix(backups[0], ".log.gz") {
+		t.Fatalf("backups = %v, want one compressed backup", backups)
+	}
+	file, err := os.Open(backups[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "compressed\n" {
+		t.Errorf("backup content = %q, want %q", content, "compressed\n")
+	}
+}
+
+func TestRotatingFileMaxAgeAcrossRestarts(t *testing.T) {
+	var (
+		dir     = t.TempDir()
+		path    = filepath.Join(dir, "app.log")
+		rotated = time.Now().Add(-2 * time.Hour)
+	)
+	// a previous run rotated the file two hours ago and kept writing to it
+	backup := filepath.Join(dir, "app-"+rotated.Format(backupTimeFormat)+".log")
+	if err := os.WriteFile(backup, []byte("old\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("current\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := OpenRotatingFile(path, FileOptions{MaxAge: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("new\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := readFile(t, path); got != "new\n" {
+		t.Errorf("file = %q, want it to be rotated before writing", got)
+	}
+	if backups := f.backups(); len(backups) != 2 {
+		t.Errorf("backups = %v, want 2", backups)
+	}
+}
+
+func TestRotatingFileMaxAgeNeverRotated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte("first run\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fileCreated(path, info); !ok {
+		t.Skip("the file system doesn't record when files were created")
+	}
+	time.Sleep(100 * time.Millisecond)
+	// the previous run kept writing to the file right up until it restarted
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := OpenRotatingFile(path, FileOptions{MaxAge: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("second run\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := readFile(t, path); got != "second run\n" {
+		t.Errorf("file = %q, want it to be rotated before writing", got)
+	}
+}
+
+func TestRotatingFileConcurrentClose(t *testing.T) {
+	f, err := OpenRotatingFile(filepath.Join(t.TempDir(), "app.log"), FileOptions{ReopenOnSIGHUP: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() { _ = f.Close() })
+	}
+	wg.Wait()
+	if _, err := f.Write([]byte("x")); err != os.ErrClosed {
+		t.Errorf("write after close = %v, want %v", err, os.ErrClosed)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
@@ -0,0 +1,16 @@
+package timber
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// fileCreated gets when a file was created if the file system records it
+func fileCreated(_ string, info os.FileInfo) (time.Time, bool) {
+	data, ok := info.Sys().(*syscall.Win32FileAttributeData)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(0, data.CreationTime.Nanoseconds()), true
+}
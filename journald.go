@@ -0,0 +1,124 @@
+package timber
+
+import (
+	"encoding/binary"
+	"strconv"
+	"strings"
+)
+
+// JournaldOptions configures a journald sink
+type JournaldOptions struct {
+	// Value of the SYSLOG_IDENTIFIER field. Defaults to the name of the
+	// executable.
+	Identifier string
+	// Path of journald's native protocol socket. Defaults to
+	// /run/systemd/journal/socket.
+	SocketPath string
+	// Minimum and maximum severity written to the journal. 0 has no limit.
+	MinLevel Severity
+	MaxLevel Severity
+}
+
+// JournaldSink creates a sink that writes to the systemd journal with its native
+// protocol. Attributes are written as journal fields with their keys converted
+// to uppercase and prefixed with F_ if they would replace a field that timber
+// writes. Entries too large for a single datagram are passed to journald in a
+// temporary file. The sink's writer should be closed once nothing else will be
+// logged to it.
+func JournaldSink(opts JournaldOptions) (Sink, error) {
+	if opts.Identifier == "" {
+		opts.Identifier = programName()
+	}
+	if opts.SocketPath == "" {
+		opts.SocketPath = "/run/systemd/journal/socket"
+	}
+	w, err := dialSocket("unixgram", opts.SocketPath)
+	if err != nil {
+		return Sink{}, err
+	}
+	w.oversized = sendJournalFile
+	return Sink{
+		Writer:   w,
+		MinLevel: opts.MinLevel,
+		MaxLevel: opts.MaxLevel,
+		encode: func(l *Logger, b []byte, e entry) []byte {
+			return l.appendJournald(b, e, opts.Identifier)
+		},
+	}, nil
+}
+
+func (l *Logger) appendJournald(b []byte, e entry, identifier string) []byte {
+	b = appendJournalField(b, "MESSAGE", e.msg)
+	b = appendJournalField(b, "PRIORITY", strconv.Itoa(syslogSeverity(e.severity)))
+	b = appendJournalField(b, "SYSLOG_IDENTIFIER", identifier)
+	if e.caller != nil {
+		file, line, _ := strings.Cut(e.caller.path, ":")
+		b = appendJournalField(b, "CODE_FILE", file)
+		b = appendJournalField(b, "CODE_LINE", line)
+		b = appendJournalField(b, "CODE_FUNC", e.caller.function)
+	}
+	if e.timed {
+		b = appendJournalField(b, "DURATION", string(appendPlainValue(nil, l.structuredDuration(e.duration))))
+	}
+	if e.err != nil {
+		b = appendJournalField(b, "ERROR", e.err.Error())
+	}
+	for _, attrs := range [][]Attr{l.bound.attrs, e.attrs} {
+		for _, attribute := range attrs {
+			flattenAttr(attribute, "", func(key string, value any) {
+				name := journalFieldName(key)
+				if reservedJournalField(name) {
+					name = "F_" + name
+				}
+				b = appendJournalField(b, name, string(appendPlainValue(nil, value)))
+			})
+		}
+	}
+	return b
+}
+
+// appendJournalField appends a field in the native journal protocol. Values
+// with newlines are written with their length since they can't end at a newline.
+func appendJournalField(b []byte, name, value string) []byte {
+	b = append(b, name...)
+	if !strings.Contains(value, "\n") {
+		b = append(b, '=')
+		b = append(b, value...)
+		return append(b, '\n')
+	}
+	b = append(b, '\n')
+	b = binary.LittleEndian.AppendUint64(b, uint64(len(value)))
+	b = append(b, value...)
+	return append(b, '\n')
+}
+
+// reservedJournalField checks if a field name is one that timber writes itself
+// so attributes can't replace it
+func reservedJournalField(name string) bool {
+	switch name {
+	case "MESSAGE", "PRIORITY", "SYSLOG_IDENTIFIER", "DURATION", "ERROR":
+		return true
+	}
+	return strings.HasPrefix(name, "CODE_")
+}
+
+// journalFieldName converts a key into a valid journal field name, which can
+// only have uppercase letters, digits, and underscores, can't start with a digit
+// or underscore, and is at most 64 characters
+func journalFieldName(key string) string {
+	b := make([]byte, 0, len(key)+2)
+	for _, c := range []byte(strings.ToUpper(key)) {
+		if (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') {
+			b = append(b, c)
+		} else {
+			b = append(b, '_')
+		}
+	}
+	if len(b) == 0 || b[0] < 'A' || b[0] > 'Z' {
+		b = append([]byte("F_"), b...)
+	}
+	if len(b) > 64 {
+		b = b[:64]
+	}
+	return string(b)
+}
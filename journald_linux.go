@@ -0,0 +1,46 @@
+package timber
+
+import (
+	"errors"
+	"net"
+	"os"
+	"syscall"
+)
+
+// sendJournalFile passes an entry that is too large for a datagram to journald
+// by writing it to an unlinked temporary file and sending the file descriptor
+func sendJournalFile(conn net.Conn, p []byte, err error) error {
+	if !errors.Is(err, syscall.EMSGSIZE) && !errors.Is(err, syscall.ENOBUFS) {
+		return err
+	}
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return err
+	}
+	f, err := os.CreateTemp("/dev/shm", "timber-journal-")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	// journald only accepts files that aren't linked into the file system
+	if err := os.Remove(f.Name()); err != nil {
+		return err
+	}
+	if _, err := f.Write(p); err != nil {
+		return err
+	}
+	raw, err := unixConn.SyscallConn()
+	if err != nil {
+		return err
+	}
+	// WriteMsgUnix can't be used since the socket is already connected
+	var sendErr error
+	err = raw.Write(func(fd uintptr) bool {
+		sendErr = syscall.Sendmsg(int(fd), nil, syscall.UnixRights(int(f.Fd())), nil, 0)
+		return sendErr != syscall.EAGAIN
+	})
+	if err != nil {
+		return err
+	}
+	return sendErr
+}
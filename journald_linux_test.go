@@ -0,0 +1,77 @@
+package timber_test
+
+import (
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"go.mattglei.ch/timber"
+)
+
+func TestJournaldSinkLargeEntry(t *testing.T) {
+	dir, err := os.MkdirTemp("", "timber")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	sink, err := timber.JournaldSink(timber.JournaldOptions{Identifier: "app", SocketPath: socket})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Writer.(*timber.SocketWriter).Close()
+
+	// far larger than the socket's send buffer so it can't be sent as a datagram
+	msg := strings.Repeat("a", 16<<20)
+	timber.New(timber.WithSinks(sink)).Info(msg)
+
+	var (
+		buf = make([]byte, 1024)
+		oob = make([]byte, syscall.CmsgSpace(4))
+	)
+	n, oobn, _, _, err := conn.ReadMsgUnix(buf, oob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Fatalf("received a %d byte datagram, want only a file descriptor", n)
+	}
+	messages, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	if err != nil || len(messages) != 1 {
+		t.Fatalf("control messages = %v, %v", messages, err)
+	}
+	fds, err := syscall.ParseUnixRights(&messages[0])
+	if err != nil || len(fds) != 1 {
+		t.Fatalf("file descriptors = %v, %v", fds, err)
+	}
+	f := os.NewFile(uintptr(fds[0]), "journal")
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if links := info.Sys().(*syscall.Stat_t).Nlink; links != 0 {
+		t.Errorf("file has %d links, journald only accepts unlinked files", links)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "MESSAGE=" + msg + "\nPRIORITY=6\n"; !strings.HasPrefix(string(data), want) {
+		t.Errorf("file starts with %q, want the entry", data[:min(len(data), 64)])
+	}
+}
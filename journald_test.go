@@ -0,0 +1,122 @@
+//go:build unix
+
+package timber_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"go.mattglei.ch/timber"
+)
+
+func TestJournaldSink(t *testing.T) {
+	socket, read := listenJournal(t)
+	sink, err := timber.JournaldSink(timber.JournaldOptions{
+		Identifier: "app",
+		SocketPath: socket,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Writer.(*timber.SocketWriter).Close()
+
+	l := timber.New(timber.WithSinks(sink))
+	l.Error(errors.New("connection reset"), "request failed",
+		timber.A("http.method", "GET"),
+		timber.A("body", "line one\nline two"),
+		timber.A("priority", 0),
+		timber.A("message", "replaced"),
+		timber.A("code_line", 1),
+		timber.A("1st", true),
+	)
+
+	fields, err := parseJournalFields(read())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][2]string{
+		{"MESSAGE", "request failed"},
+		{"PRIORITY", "3"},
+		{"SYSLOG_IDENTIFIER", "app"},
+		{"ERROR", "connection reset"},
+		{"HTTP_METHOD", "GET"},
+		{"BODY", "line one\nline two"},
+		{"F_PRIORITY", "0"},
+		{"F_MESSAGE", "replaced"},
+		{"F_CODE_LINE", "1"},
+		{"F_1ST", "true"},
+	}
+	if !slices.Equal(fields, want) {
+		t.Errorf("fields = %q\nwant %q", fields, want)
+	}
+}
+
+func TestJournaldSinkBinaryField(t *testing.T) {
+	socket, read := listenJournal(t)
+	sink, err := timber.JournaldSink(timber.JournaldOptions{Identifier: "app", SocketPath: socket})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Writer.(*timber.SocketWriter).Close()
+
+	timber.New(timber.WithSinks(sink)).Info("first\nsecond")
+
+	// values with a newline are written as the name, a newline, the length as a
+	// little endian uint64, the value, and a newline
+	want := []byte("MESSAGE\n\x0c\x00\x00\x00\x00\x00\x00\x00first\nsecond\nPRIORITY=6\n")
+	if got := read(); !bytes.HasPrefix(got, want) {
+		t.Errorf("datagram = %q, want prefix %q", got, want)
+	}
+}
+
+// listenJournal starts a stand-in for journald's socket and returns its path
+// and a function that reads one datagram from it
+func listenJournal(t *testing.T) (string, func() []byte) {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "timber")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "journal.sock")
+	addr, read := listenPacket(t, "unixgram", socket)
+	return addr, func() []byte { return []byte(read()) }
+}
+
+// parseJournalFields decodes a datagram in the native journal protocol
+func parseJournalFields(b []byte) ([][2]string, error) {
+	var fields [][2]string
+	for len(b) > 0 {
+		end := bytes.IndexAny(b, "=\n")
+		if end < 0 {
+			return nil, errors.New("field without a value")
+		}
+		name := string(b[:end])
+		if b[end] == '=' {
+			value, rest, found := bytes.Cut(b[end+1:], []byte("\n"))
+			if !found {
+				return nil, errors.New("unterminated field " + name)
+			}
+			fields = append(fields, [2]string{name, string(value)})
+			b = rest
+			continue
+		}
+		b = b[end+1:]
+		if len(b) < 8 {
+			return nil, errors.New("missing length of field " + name)
+		}
+		n := binary.LittleEndian.Uint64(b)
+		b = b[8:]
+		if uint64(len(b)) < n+1 || b[n] != '\n' {
+			return nil, errors.New("invalid length of field " + name)
+		}
+		fields = append(fields, [2]string{name, string(b[:n])})
+		b = b[n+1:]
+	}
+	return fields, nil
+}
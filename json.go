@@ -0,0 +1,176 @@
+package timber
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+type jsonFrame struct {
+	Function string `json:"function"`
+	Path     string `json:"path,omitempty"`
+	Repeated int    `json:"repeated,omitempty"`
+}
+
+func (l *Logger) appendJSON(b []byte, e entry, _ *sink) []byte {
+	b = append(b, '{')
+	// records from slog without a time are written without one
+	showTime := l.displayTime && !e.time.IsZero()
+	if showTime {
+		b = append(b, `"time":"`...)
+		start := len(b)
+		b = e.time.UTC().AppendFormat(b, l.structured.timeFormat)
+		if jsonSafe(b[start:]) {
+			b = append(b, '"')
+		} else {
+			// the time format has characters that need to be escaped
+			text := string(b[start:])
+			b = appendJSONField(b[:start-len(`"time":"`)], "time", text, true)
+		}
+	}
+	if showTime {
+		b = append(b, ',')
+	}
+	b = append(b, `"level":`...)
+	b = appendJSONString(b, e.level.Message)
+	b = append(b, `,"msg":`...)
+	b = appendJSONString(b, e.msg)
+	if e.caller != nil {
+		for _, attribute := range l.structuredCaller(*e.caller) {
+			b = appendJSONField(b, attribute.Key, attribute.Value, false)
+		}
+	}
+	if e.timed {
+		b = appendJSONField(b, "duration", l.structuredDuration(e.duration), false)
+	}
+	b = append(b, l.bound.json...)
+	if e.err != nil {
+		b = appendJSONField(b, "error", e.err.Error(), false)
+		if causes := errorCauses(e.err); len(causes) != 0 {
+			b = appendJSONField(b, "error.causes", causes, false)
+		}
+	}
+	for _, attribute := range e.attrs {
+		b = appendJSONField(b, attribute.Key, attribute.Value, false)
+	}
+	if len(e.stack) != 0 {
+		stack := make([]jsonFrame, 0, len(e.stack))
+		for _, f := range e.stack {
+			jf := jsonFrame{Function: f.function + "()", Path: f.path}
+			if f.repeated != 0 {
+				jf.Repeated = f.repeated + 1
+			}
+			stack = append(stack, jf)
+		}
+		b = appendJSONField(b, "stack", stack, false)
+		if e.stackHidden != 0 {
+			b = appendJSONField(b, "stack.truncated", e.stackHidden, false)
+		}
+	}
+	return append(b, '}')
+}
+
+func appendJSONField(b []byte, key string, value any, first bool) []byte {
+	if !first {
+		b = append(b, ',')
+	}
+	b = appendJSONString(b, key)
+	b = append(b, ':')
+	return appendJSONValue(b, value)
+}
+
+func appendJSONString(b []byte, s string) []byte {
+	if !jsonSafe(s) {
+		return append(b, encodeJSON(s)...)
+	}
+	b = append(b, '"')
+	b = append(b, s...)
+	return append(b, '"')
+}
+
+// appendJSONValue encodes a value as JSON with fast paths for common types
+func appendJSONValue(b []byte, value any) []byte {
+	switch v := value.(type) {
+	case nil:
+		return append(b, "null"...)
+	case string:
+		return appendJSONString(b, v)
+	case bool:
+		return strconv.AppendBool(b, v)
+	case int:
+		return strconv.AppendInt(b, int64(v), 10)
+	case int8:
+		return strconv.AppendInt(b, int64(v), 10)
+	case int16:
+		return strconv.AppendInt(b, int64(v), 10)
+	case int32:
+		return strconv.AppendInt(b, int64(v), 10)
+	case int64:
+		return strconv.AppendInt(b, v, 10)
+	case uint:
+		return strconv.AppendUint(b, uint64(v), 10)
+	case uint8:
+		return strconv.AppendUint(b, uint64(v), 10)
+	case uint16:
+		return strconv.AppendUint(b, uint64(v), 10)
+	case uint32:
+		return strconv.AppendUint(b, uint64(v), 10)
+	case uint64:
+		return strconv.AppendUint(b, v, 10)
+	}
+	return append(b, encodeJSON(jsonValue(value))...)
+}
+
+// jsonSafe checks if a string can be written in JSON without escaping anything
+func jsonSafe[T string | []byte](s T) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < ' ' || c == '"' || c == '\\' || c >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// convert values that encoding/json would otherwise encode poorly
+func jsonValue(value any) any {
+	if nilPointer(value) {
+		return nil
+	}
+	switch v := value.(type) {
+	case json.Marshaler, encoding.TextMarshaler:
+		return v
+	case time.Duration:
+		// durations stay numbers like the duration field
+		return int64(v)
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	}
+	return value
+}
+
+// nilPointer checks if a value is a typed nil pointer, which would panic if its
+// methods were called
+func nilPointer(value any) bool {
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+func encodeJSON(value any) []byte {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		// values such as channels and functions can't be encoded so just fall back on
+		// their string representation
+		buf.Reset()
+		_ = encoder.Encode(fmt.Sprint(value))
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
@@ -1,7 +1,9 @@
 package timber
 
 import (
-	"fmt"
+	"io"
+	"maps"
+	"slices"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -18,45 +20,242 @@ type Levels struct {
 
 // A given level for logging
 type Level struct {
-	Style       lipgloss.Style
-	Message     string
-	renderedMsg string
+	Style   lipgloss.Style
+	Message string
 }
 
-func (l *Level) render() {
-	l.renderedMsg = l.Style.Render(fmt.Sprintf("%-5s", l.Message))
+// Severity orders the levels that timber logs at from least to most severe
+type Severity int
+
+const (
+	SeverityDebug   Severity = 10
+	SeverityInfo    Severity = 20
+	SeverityDone    Severity = 30
+	SeverityWarning Severity = 40
+	SeverityError   Severity = 50
+	SeverityFatal   Severity = 60
+)
+
+// definedLevel is a level in a logger's level registry
+type definedLevel struct {
+	Level
+	showStack bool
+}
+
+// levelRegistry creates a level registry with the built-in levels
+func levelRegistry(levels Levels) map[Severity]definedLevel {
+	return map[Severity]definedLevel{
+		SeverityDebug:   {Level: levels.Debug},
+		SeverityInfo:    {Level: levels.Info},
+		SeverityDone:    {Level: levels.Done},
+		SeverityWarning: {Level: levels.Warning},
+		SeverityError:   {Level: levels.Error},
+		SeverityFatal:   {Level: levels.Fatal},
+	}
 }
 
-func (l *Level) style(style lipgloss.Style) {
-	globalLogger.mutex.Lock()
-	defer globalLogger.mutex.Unlock()
-	l.Style = style
-	l.render()
+// updateLevel changes a level in the registry. The registry is copied first
+// since it can be shared with loggers created with With.
+func (l *Logger) updateLevel(severity Severity, fn func(level *definedLevel)) {
+	l.levels = maps.Clone(l.levels)
+	level := l.levels[severity]
+	fn(&level)
+	l.levels[severity] = level
+	l.renderLevels()
 }
 
-func (l *Level) set(newLevel Level) {
-	globalLogger.mutex.Lock()
-	defer globalLogger.mutex.Unlock()
-	*l = newLevel
-	l.render()
+func (l *Logger) setLevel(severity Severity, newLevel Level) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.updateLevel(severity, func(level *definedLevel) { level.Level = newLevel })
 }
 
-func renderLevels(logger *logger, normalLevels bool, errLevels bool) {
-	var levels []*Level
-	if normalLevels {
-		levels = append(levels,
-			&logger.levels.Debug,
-			&logger.levels.Info,
-			&logger.levels.Done,
-			&logger.levels.Warning,
-		)
+func (l *Logger) setLevelStyle(severity Severity, style lipgloss.Style) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.updateLevel(severity, func(level *definedLevel) { level.Style = style })
+}
+
+// LevelDefinition describes a custom level that can be logged at with Log
+type LevelDefinition struct {
+	Severity Severity
+	Name     string
+	Style    lipgloss.Style
+	// Writers that entries at the level are routed to. Defaults to the writers
+	// of the closest built-in level below it.
+	Writers []io.Writer
+	// Show a stack trace with entries at the level. For the ERROR and FATAL
+	// levels this sets ShowErrorStack and ShowFatalStack.
+	ShowStack bool
+}
+
+// Define a custom level or redefine an existing one. Only the FATAL level exits
+// the program.
+func (l *Logger) DefineLevel(def LevelDefinition) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.updateLevel(def.Severity, func(level *definedLevel) {
+		level.Level = Level{Style: def.Style, Message: def.Name}
+		level.showStack = def.ShowStack
+	})
+	switch def.Severity {
+	case SeverityError:
+		l.showErrorStack = def.ShowStack
+	case SeverityFatal:
+		l.showFatalStack = def.ShowStack
 	}
-	if errLevels {
-		levels = append(levels, &logger.levels.Error, &logger.levels.Fatal)
+	writers := def.Writers
+	if writers == nil {
+		writers = l.routes[builtinSeverity(def.Severity)]
 	}
-	for _, level := range levels {
-		level.render()
+	l.route(def.Severity, writers...)
+	l.routeSinks()
+}
+
+// builtinSeverity gets the closest built-in severity at or below severity
+func builtinSeverity(severity Severity) Severity {
+	builtin := SeverityDebug
+	for _, s := range []Severity{SeverityInfo, SeverityDone, SeverityWarning, SeverityError, SeverityFatal} {
+		if s <= severity {
+			builtin = s
+		}
+	}
+	return builtin
+}
+
+// definedSeverity gets the closest defined severity at or below severity, or the
+// lowest defined severity if there isn't one
+func (l *Logger) definedSeverity(severity Severity) Severity {
+	if _, ok := l.levels[severity]; ok {
+		return severity
+	}
+	defined := slices.Sorted(maps.Keys(l.levels))
+	closest := defined[0]
+	for _, s := range defined {
+		if s <= severity {
+			closest = s
+		}
 	}
+	return closest
+}
+
+// Set the levels that timber logs at.
+//
+// Default:
+// DEBUG - Bold #2B95FF
+// INFO  - Bold
+// DONE  - Bold #30CE75
+// WARN  - Bold #E1DC3F
+// ERROR - Bold #FF4747
+// FATAL - Bold #FF4747
+func (l *Logger) SetLevels(levels Levels) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.levels = maps.Clone(l.levels)
+	maps.Copy(l.levels, levelRegistry(levels))
+	l.renderLevels()
+}
+
+// Get the current built-in levels
+func (l *Logger) GetLevels() Levels {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return Levels{
+		Debug:   l.levels[SeverityDebug].Level,
+		Info:    l.levels[SeverityInfo].Level,
+		Done:    l.levels[SeverityDone].Level,
+		Warning: l.levels[SeverityWarning].Level,
+		Error:   l.levels[SeverityError].Level,
+		Fatal:   l.levels[SeverityFatal].Level,
+	}
+}
+
+// Set the minimum severity that will be logged. Fatal calls will still exit
+// even if the FATAL level is filtered out.
+//
+// Default is SeverityDebug
+func (l *Logger) SetMinLevel(severity Severity) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.minLevel = severity
+}
+
+// Get the minimum severity that will be logged
+func (l *Logger) GetMinLevel() Severity {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return l.minLevel
+}
+
+// Check if logs at the given severity will be output
+func (l *Logger) Enabled(severity Severity) bool {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return l.enabled(severity)
+}
+
+func (l *Logger) enabled(severity Severity) bool {
+	return severity >= l.minLevel
+}
+
+// Set the level for the debug level
+func (l *Logger) SetDebug(level Level) {
+	l.setLevel(SeverityDebug, level)
+}
+
+// Set the style for the debug level
+func (l *Logger) SetDebugStyle(s lipgloss.Style) {
+	l.setLevelStyle(SeverityDebug, s)
+}
+
+// Set the level for the info level
+func (l *Logger) SetInfo(level Level) {
+	l.setLevel(SeverityInfo, level)
+}
+
+// Set the style for the info level
+func (l *Logger) SetInfoStyle(s lipgloss.Style) {
+	l.setLevelStyle(SeverityInfo, s)
+}
+
+// Set the level for the done level
+func (l *Logger) SetDone(level Level) {
+	l.setLevel(SeverityDone, level)
+}
+
+// Set the style for the done level
+func (l *Logger) SetDoneStyle(s lipgloss.Style) {
+	l.setLevelStyle(SeverityDone, s)
+}
+
+// Set the level for the warning level
+func (l *Logger) SetWarning(level Level) {
+	l.setLevel(SeverityWarning, level)
+}
+
+// Set the style for the warning level
+func (l *Logger) SetWarningStyle(s lipgloss.Style) {
+	l.setLevelStyle(SeverityWarning, s)
+}
+
+// Set the level for the error level
+func (l *Logger) SetError(level Level) {
+	l.setLevel(SeverityError, level)
+}
+
+// Set the style for the error level
+func (l *Logger) SetErrorStyle(s lipgloss.Style) {
+	l.setLevelStyle(SeverityError, s)
+}
+
+// Set the level for the fatal level
+func (l *Logger) SetFatal(level Level) {
+	l.setLevel(SeverityFatal, level)
+}
+
+// Set the style for the fatal level
+func (l *Logger) SetFatalStyle(s lipgloss.Style) {
+	l.setLevelStyle(SeverityFatal, s)
 }
 
 // Set the levels that timber logs at.
@@ -69,73 +268,89 @@ func renderLevels(logger *logger, normalLevels bool, errLevels bool) {
 // ERROR - Bold #FF4747
 // FATAL - Bold #FF4747
 func SetLevels(levels Levels) {
-	globalLogger.mutex.Lock()
-	defer globalLogger.mutex.Unlock()
-	globalLogger.levels = levels
-	renderLevels(globalLogger, true, true)
+	globalLogger.SetLevels(levels)
 }
 
-// Get the current levels
+// Get the current built-in levels
 func GetLevels() Levels {
-	return globalLogger.levels
+	return globalLogger.GetLevels()
+}
+
+// Define a custom level or redefine an existing one. Only the FATAL level exits
+// the program.
+func DefineLevel(def LevelDefinition) {
+	globalLogger.DefineLevel(def)
+}
+
+// Set the minimum severity that will be logged. Fatal calls will still exit
+// even if the FATAL level is filtered out.
+//
+// Default is SeverityDebug
+func SetMinLevel(severity Severity) {
+	globalLogger.SetMinLevel(severity)
+}
+
+// Get the minimum severity that will be logged
+func GetMinLevel() Severity {
+	return globalLogger.GetMinLevel()
 }
 
 // Set the level for the debug level
 func SetDebug(l Level) {
-	globalLogger.levels.Debug.set(l)
+	globalLogger.SetDebug(l)
 }
 
 // Set the style for the debug level
 func SetDebugStyle(s lipgloss.Style) {
-	globalLogger.levels.Debug.style(s)
+	globalLogger.SetDebugStyle(s)
 }
 
 // Set the level for the info level
 func SetInfo(l Level) {
-	globalLogger.levels.Info.set(l)
+	globalLogger.SetInfo(l)
 }
 
 // Set the style for the info level
 func SetInfoStyle(s lipgloss.Style) {
-	globalLogger.levels.Info.style(s)
+	globalLogger.SetInfoStyle(s)
 }
 
 // Set the level for the done level
 func SetDone(l Level) {
-	globalLogger.levels.Done.set(l)
+	globalLogger.SetDone(l)
 }
 
 // Set the style for the done level
 func SetDoneStyle(s lipgloss.Style) {
-	globalLogger.levels.Done.style(s)
+	globalLogger.SetDoneStyle(s)
 }
 
 // Set the level for the warning level
 func SetWarning(l Level) {
-	globalLogger.levels.Warning.set(l)
+	globalLogger.SetWarning(l)
 }
 
 // Set the style for the warning level
 func SetWarningStyle(s lipgloss.Style) {
-	globalLogger.levels.Warning.style(s)
+	globalLogger.SetWarningStyle(s)
 }
 
 // Set the level for the error level
 func SetError(l Level) {
-	globalLogger.levels.Error.set(l)
+	globalLogger.SetError(l)
 }
 
 // Set the style for the error level
 func SetErrorStyle(s lipgloss.Style) {
-	globalLogger.levels.Error.style(s)
+	globalLogger.SetErrorStyle(s)
 }
 
 // Set the level for the fatal level
 func SetFatal(l Level) {
-	globalLogger.levels.Fatal.set(l)
+	globalLogger.SetFatal(l)
 }
 
 // Set the style for the fatal level
 func SetFatalStyle(s lipgloss.Style) {
-	globalLogger.levels.Fatal.style(s)
+	globalLogger.SetFatalStyle(s)
 }
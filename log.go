@@ -1,119 +1,338 @@
 package timber
 
 import (
+	"bytes"
 	"fmt"
-	"strings"
+	"math"
+	"strconv"
+	"sync"
 	"time"
 )
 
-func formatLog(level Level, msg string, start time.Time, attrs []Attr) string {
-	if globalLogger.structured.enabled {
-		return formatStructured(level, msg, start, attrs)
+// entry is a single log entry. Every logging function builds an entry which is
+// then written in the logger's format.
+type entry struct {
+	severity Severity
+	level    Level
+	time     time.Time
+	msg      string
+	timed    bool
+	duration time.Duration
+	err      error
+	attrs    []Attr
+	caller   *caller
+	stack    []stackFrame
+	// number of frames left out of the stack by StackOptions.MaxDepth
+	stackHidden int
+}
+
+// log is the single path that every logging function goes through. Fatal
+// entries exit the program after they are written.
+func (l *Logger) log(severity Severity, start time.Time, err error, msg string, attrs []Attr) {
+	l.output(severity, start, err, msg, attrs)
+	if severity == SeverityFatal {
+		l.exit()
+	}
+}
+
+// output writes an entry if its severity is enabled
+func (l *Logger) output(severity Severity, start time.Time, err error, msg string, attrs []Attr) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	if l.enabled(severity) {
+		l.write(l.newEntry(severity, start, err, msg, attrs))
 	}
-	return formatPlain(level, msg, start, attrs)
 }
 
-func formatStructured(level Level, msg string, start time.Time, attrs []Attr) string {
+func (l *Logger) newEntry(
+	severity Severity,
+	start time.Time,
+	err error,
+	msg string,
+	attrs []Attr,
+) entry {
+	e := entry{
+		severity: severity,
+		level:    l.level(severity),
+		time:     time.Now(),
+		msg:      msg,
+		err:      err,
+		attrs:    attrs,
+	}
 	if !start.IsZero() {
-		attrs = append([]Attr{{"duration", formatDuration(time.Since(start))}}, attrs...)
-	}
-	out := make([]string, 0, 3+len(attrs))
-	out = append(out,
-		time.Now().UTC().Format(globalLogger.structured.timeFormat),
-		fmt.Sprintf("level=%q", level.Message),
-		fmt.Sprintf("msg=%q", msg),
-	)
-	if len(attrs) > 0 {
-		fmtValues := make([]string, 0, len(attrs))
-		for _, attribute := range attrs {
-			fmtValues = append(
-				fmtValues,
-				fmt.Sprintf(`%s="%v"`, attribute.Key, attribute.Value),
-			)
+		e.timed = true
+		e.duration = e.time.Sub(start)
+	}
+	if err != nil {
+		e.attrs = append(errorAttrs(err), attrs...)
+	}
+	if l.callers.enabled {
+		if c, ok := l.caller(); ok {
+			e.caller = &c
 		}
-		out = append(out, strings.Join(fmtValues, " "))
 	}
-	return strings.Join(out, " ")
+	if l.showStack(severity) {
+		stack := stackFromError(err)
+		if stack == nil {
+			stack = framesFromCall()
+		}
+		e.stack, e.stackHidden = l.stack.apply(stack)
+	}
+	return e
 }
 
-func formatPlain(level Level, msg string, start time.Time, attrs []Attr) string {
-	if !start.IsZero() {
-		msg = fmt.Sprintf("%s (%s)", msg, formatDuration(time.Since(start)))
-	}
-	out := make([]string, 0, 3)
-	out = append(out,
-		time.Now().In(globalLogger.timezone).Format(globalLogger.timeFormat),
-		level.renderedMsg,
-		msg,
-	)
-	if len(attrs) > 0 {
-		fmtValues := make([]string, 0, len(attrs))
-		for _, attribute := range attrs {
-			fmtValues = append(
-				fmtValues,
-				fmt.Sprintf("%s: %v", attribute.Key, attribute.Value),
-			)
+// level gets the level that entries with the given severity are logged at
+func (l *Logger) level(severity Severity) Level {
+	return l.levels[severity].Level
+}
+
+func (l *Logger) showStack(severity Severity) bool {
+	switch severity {
+	case SeverityError:
+		return l.showErrorStack
+	case SeverityFatal:
+		return l.showFatalStack
+	default:
+		return l.levels[severity].showStack
+	}
+}
+
+// buffers that entries are formatted into
+var bufferPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 0, 1024)
+		return &b
+	},
+}
+
+// buffers larger than this aren't returned to the pool so that one large entry
+// doesn't hold on to memory forever
+const maxPooledBuffer = 64 << 10
+
+func (l *Logger) write(e entry) {
+	for i := range l.sinks {
+		s := &l.sinks[i]
+		if s.accepts(e.severity) {
+			l.writeSink(s, e)
 		}
-		out = append(out, "["+strings.Join(fmtValues, ", ")+"]")
 	}
-	return strings.Join(out, " ")
 }
 
-func outputNormal(s string) {
-	globalLogger.normalOutput.logger.Print(s)
+func (l *Logger) writeSink(s *sink, e entry) {
+	buf := bufferPool.Get().(*[]byte)
+	b := (*buf)[:0]
+	if s.encode != nil {
+		// encoders write complete messages for their protocol
+		b = s.encode(l, b, e)
+	} else {
+		switch s.Format {
+		case Logfmt:
+			b = l.appendStructured(b, e, s)
+		case JSON:
+			b = l.appendJSON(b, e, s)
+		default:
+			b = l.appendPlain(b, e, s)
+		}
+		if len(b) == 0 || b[len(b)-1] != '\n' {
+			b = append(b, '\n')
+		}
+	}
+
+	if l.async == nil || !l.async.enqueue(s, string(b)) {
+		s.write(b)
+	}
+
+	if cap(b) <= maxPooledBuffer {
+		*buf = b
+		bufferPool.Put(buf)
+	}
 }
 
-func logNormal(level Level, msg string, attrs []Attr) {
-	outputNormal(formatLog(level, msg, time.Time{}, attrs))
+func (l *Logger) appendStructured(b []byte, e entry, s *sink) []byte {
+	if l.displayTime && !e.time.IsZero() {
+		b = e.time.UTC().AppendFormat(b, l.structured.timeFormat)
+		b = append(b, ' ')
+	}
+	b = append(b, "level="...)
+	b = appendLogfmtString(b, e.level.Message)
+	b = append(b, " msg="...)
+	b = appendLogfmtString(b, e.msg)
+	if e.caller != nil {
+		for _, attribute := range l.structuredCaller(*e.caller) {
+			b = append(b, ' ')
+			b = appendStructuredAttr(b, attribute)
+		}
+	}
+	if e.timed {
+		b = append(b, " duration="...)
+		switch l.structured.durationUnit {
+		case DurationNanoseconds:
+			b = strconv.AppendInt(b, e.duration.Nanoseconds(), 10)
+		case DurationSeconds:
+			b = strconv.AppendFloat(b, e.duration.Seconds(), 'g', -1, 64)
+		default:
+			b = appendLogfmtString(b, l.durationFormatter(e.duration))
+		}
+	}
+	if l.bound.structured != "" {
+		b = append(b, ' ')
+		b = append(b, l.bound.structured...)
+	}
+	if e.err != nil {
+		for _, attribute := range structuredErrorAttrs(e.err) {
+			b = append(b, ' ')
+			b = appendStructuredAttr(b, attribute)
+		}
+	}
+	for _, attribute := range e.attrs {
+		b = append(b, ' ')
+		b = appendStructuredAttr(b, attribute)
+	}
+	if len(e.stack) != 0 {
+		// the stack is kept in one value so that every entry stays on one line
+		stack := l.appendStackTrace(nil, e.stack, e.stackHidden, asciiRenderer)
+		b = append(b, " stack="...)
+		b = appendLogfmtString(b, string(bytes.Trim(stack, "\n")))
+	}
+	return b
 }
 
-func logDurationNormal(level Level, start time.Time, msg string, attrs []Attr) {
-	outputNormal(formatLog(level, msg, start, attrs))
+func appendStructuredAttr(b []byte, attribute Attr) []byte {
+	if g, ok := attribute.Value.(group); ok {
+		for i, groupAttr := range g {
+			if i != 0 {
+				b = append(b, ' ')
+			}
+			groupAttr.Key = attribute.Key + "." + groupAttr.Key
+			b = appendStructuredAttr(b, groupAttr)
+		}
+		return b
+	}
+	b = appendLogfmtKey(b, attribute.Key)
+	b = append(b, '=')
+	return appendLogfmtValue(b, attribute.Value)
 }
 
-func outputError(
-	level Level,
-	err error,
-	msg string,
-	start time.Time,
-	vals []Attr,
-	outputStack bool,
-) {
-	structured := globalLogger.structured.enabled
-	var errText string
-	if err != nil {
-		errText = err.Error()
-		if structured {
-			vals = append([]Attr{{"error", errText}}, vals...)
+// structuredErrorAttrs gets the attributes that describe an error in structured logs
+func structuredErrorAttrs(err error) []Attr {
+	attrs := []Attr{{"error", err.Error()}}
+	if causes := errorCauses(err); len(causes) != 0 {
+		causeAttrs := make([]Attr, 0, len(causes))
+		for i, cause := range causes {
+			causeAttrs = append(causeAttrs, Attr{strconv.Itoa(i), cause})
+		}
+		attrs = append(attrs, Group("error.causes", causeAttrs...))
+	}
+	return attrs
+}
+
+func (l *Logger) appendPlain(b []byte, e entry, s *sink) []byte {
+	if l.displayTime && !e.time.IsZero() {
+		b = e.time.In(l.timezone).AppendFormat(b, l.timeFormat)
+		b = append(b, ' ')
+	}
+	b = append(b, s.levels[e.severity]...)
+	if e.caller != nil {
+		b = append(b, ' ')
+		b = append(b, l.plainCaller(*e.caller, s.renderer)...)
+	}
+	b = append(b, ' ')
+	b = append(b, e.msg...)
+	if e.timed {
+		b = append(b, " ("...)
+		b = append(b, l.durationFormatter(e.duration)...)
+		b = append(b, ')')
+	}
+	if len(e.attrs) > 0 || l.bound.plain != "" {
+		b = append(b, " ["...)
+		b = append(b, l.bound.plain...)
+		for i, attribute := range e.attrs {
+			if i != 0 || l.bound.plain != "" {
+				b = append(b, ", "...)
+			}
+			b = appendPlainAttr(b, attribute)
 		}
+		b = append(b, ']')
 	}
-	out := formatLog(level, msg, start, vals)
-	if err != nil && !structured {
-		out += "\n" + errText
+	if e.err != nil {
+		b = append(b, '\n')
+		b = append(b, plainError(e.level.Style.Renderer(s.renderer), e.err)...)
 	}
-	if outputStack {
-		stackTrace(&out, 5)
+	return l.appendStackTrace(b, e.stack, e.stackHidden, s.renderer)
+}
+
+func appendPlainAttr(b []byte, attribute Attr) []byte {
+	b = append(b, attribute.Key...)
+	b = append(b, ": "...)
+	if g, ok := attribute.Value.(group); ok {
+		b = append(b, '{')
+		for i, groupAttr := range g {
+			if i != 0 {
+				b = append(b, ", "...)
+			}
+			b = appendPlainAttr(b, groupAttr)
+		}
+		return append(b, '}')
 	}
-	globalLogger.errOutput.logger.Print(out)
+	return appendPlainValue(b, attribute.Value)
 }
 
-func logError(
-	level Level,
-	err error,
-	msg string,
-	attrs []Attr,
-	outputStack bool,
-) {
-	outputError(level, err, msg, time.Time{}, attrs, outputStack)
+// appendPlainValue formats a value the same as the %v verb with fast paths for
+// common types
+func appendPlainValue(b []byte, value any) []byte {
+	switch v := value.(type) {
+	case string:
+		return append(b, v...)
+	case bool:
+		return strconv.AppendBool(b, v)
+	case int:
+		return strconv.AppendInt(b, int64(v), 10)
+	case int8:
+		return strconv.AppendInt(b, int64(v), 10)
+	case int16:
+		return strconv.AppendInt(b, int64(v), 10)
+	case int32:
+		return strconv.AppendInt(b, int64(v), 10)
+	case int64:
+		return strconv.AppendInt(b, v, 10)
+	case uint:
+		return strconv.AppendUint(b, uint64(v), 10)
+	case uint8:
+		return strconv.AppendUint(b, uint64(v), 10)
+	case uint16:
+		return strconv.AppendUint(b, uint64(v), 10)
+	case uint32:
+		return strconv.AppendUint(b, uint64(v), 10)
+	case uint64:
+		return strconv.AppendUint(b, v, 10)
+	case float32:
+		if !math.IsInf(float64(v), 0) && !math.IsNaN(float64(v)) {
+			return strconv.AppendFloat(b, float64(v), 'g', -1, 32)
+		}
+	case float64:
+		if !math.IsInf(v, 0) && !math.IsNaN(v) {
+			return strconv.AppendFloat(b, v, 'g', -1, 64)
+		}
+	case time.Duration:
+		return append(b, v.String()...)
+	case time.Time:
+		// times with a monotonic clock reading include it when printed
+		if v == v.Round(0) {
+			return v.AppendFormat(b, "2006-01-02 15:04:05.999999999 -0700 MST")
+		}
+	}
+	return fmt.Append(b, value)
 }
 
-func logDurationError(
-	level Level,
-	err error,
-	start time.Time,
-	msg string,
-	attrs []Attr,
-	outputStack bool,
-) {
-	outputError(level, err, msg, start, attrs, outputStack)
+// structuredDuration converts a duration into the value used for the duration
+// field of structured logs
+func (l *Logger) structuredDuration(d time.Duration) any {
+	switch l.structured.durationUnit {
+	case DurationNanoseconds:
+		return d.Nanoseconds()
+	case DurationSeconds:
+		return d.Seconds()
+	default:
+		return l.durationFormatter(d)
+	}
 }
@@ -0,0 +1,428 @@
+package timber_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.mattglei.ch/timber"
+)
+
+func TestLoggingFunctions(t *testing.T) {
+	var (
+		err   = errors.New("something broke")
+		start = time.Now().Add(-time.Second)
+	)
+	tests := []struct {
+		name     string
+		method   func(l *timber.Logger)
+		global   func()
+		severity timber.Severity
+		level    string
+		timed    bool
+		withErr  bool
+	}{
+		{
+			name:     "Debug",
+			method:   func(l *timber.Logger) { l.Debug("msg") },
+			global:   func() { timber.Debug("msg") },
+			severity: timber.SeverityDebug,
+			level:    "DEBUG",
+		},
+		{
+			name:     "DebugSince",
+			method:   func(l *timber.Logger) { l.DebugSince(start, "msg") },
+			global:   func() { timber.DebugSince(start, "msg") },
+			severity: timber.SeverityDebug,
+			level:    "DEBUG",
+			timed:    true,
+		},
+		{
+			name:     "Info",
+			method:   func(l *timber.Logger) { l.Info("msg") },
+			global:   func() { timber.Info("msg") },
+			severity: timber.SeverityInfo,
+			level:    "INFO ",
+		},
+		{
+			name:     "InfoSince",
+			method:   func(l *timber.Logger) { l.InfoSince(start, "msg") },
+			global:   func() { timber.InfoSince(start, "msg") },
+			severity: timber.SeverityInfo,
+			level:    "INFO ",
+			timed:    true,
+		},
+		{
+			name:     "Done",
+			method:   func(l *timber.Logger) { l.Done("msg") },
+			global:   func() { timber.Done("msg") },
+			severity: timber.SeverityDone,
+			level:    "DONE ",
+		},
+		{
+			name:     "DoneSince",
+			method:   func(l *timber.Logger) { l.DoneSince(start, "msg") },
+			global:   func() { timber.DoneSince(start, "msg") },
+			severity: timber.SeverityDone,
+			level:    "DONE ",
+			timed:    true,
+		},
+		{
+			name:     "Warning",
+			method:   func(l *timber.Logger) { l.Warning("msg") },
+			global:   func() { timber.Warning("msg") },
+			severity: timber.SeverityWarning,
+			level:    "WARN ",
+		},
+		{
+			name:     "WarningSince",
+			method:   func(l *timber.Logger) { l.WarningSince(start, "msg") },
+			global:   func() { timber.WarningSince(start, "msg") },
+			severity: timber.SeverityWarning,
+			level:    "WARN ",
+			timed:    true,
+		},
+		{
+			name:     "Error",
+			method:   func(l *timber.Logger) { l.Error(err, "msg") },
+			global:   func() { timber.Error(err, "msg") },
+			severity: timber.SeverityError,
+			level:    "ERROR",
+			withErr:  true,
+		},
+		{
+			name:     "ErrorSince",
+			method:   func(l *timber.Logger) { l.ErrorSince(err, start, "msg") },
+			global:   func() { timber.ErrorSince(err, start, "msg") },
+			severity: timber.SeverityError,
+			level:    "ERROR",
+			timed:    true,
+			withErr:  true,
+		},
+		{
+			name:     "ErrorMsg",
+			method:   func(l *timber.Logger) { l.ErrorMsg("msg") },
+			global:   func() { timber.ErrorMsg("msg") },
+			severity: timber.SeverityError,
+			level:    "ERROR",
+		},
+		{
+			name:     "ErrorMsgSince",
+			method:   func(l *timber.Logger) { l.ErrorMsgSince(start, "msg") },
+			global:   func() { timber.ErrorMsgSince(start, "msg") },
+			severity: timber.SeverityError,
+			level:    "ERROR",
+			timed:    true,
+		},
+		{
+			name:     "Fatal",
+			method:   func(l *timber.Logger) { l.Fatal(err, "msg") },
+			global:   func() { timber.Fatal(err, "msg") },
+			severity: timber.SeverityFatal,
+			level:    "FATAL",
+			withErr:  true,
+		},
+		{
+			name:     "FatalSince",
+			method:   func(l *timber.Logger) { l.FatalSince(err, start, "msg") },
+			global:   func() { timber.FatalSince(err, start, "msg") },
+			severity: timber.SeverityFatal,
+			level:    "FATAL",
+			timed:    true,
+			withErr:  true,
+		},
+		{
+			name:     "FatalMsg",
+			method:   func(l *timber.Logger) { l.FatalMsg("msg") },
+			global:   func() { timber.FatalMsg("msg") },
+			severity: timber.SeverityFatal,
+			level:    "FATAL",
+		},
+		{
+			name:     "FatalMsgSince",
+			method:   func(l *timber.Logger) { l.FatalMsgSince(start, "msg") },
+			global:   func() { timber.FatalMsgSince(start, "msg") },
+			severity: timber.SeverityFatal,
+			level:    "FATAL",
+			timed:    true,
+		},
+		{
+			name:     "Log",
+			method:   func(l *timber.Logger) { l.Log(timber.SeverityWarning, "msg") },
+			global:   func() { timber.Log(timber.SeverityWarning, "msg") },
+			severity: timber.SeverityWarning,
+			level:    "WARN ",
+		},
+	}
+
+	stacks := []struct {
+		name                   string
+		errorStack, fatalStack bool
+	}{
+		{"error stack only", true, false},
+		{"fatal stack only", false, true},
+	}
+
+	for _, tt := range tests {
+		for _, stack := range stacks {
+			for _, variant := range []string{"method", "global"} {
+				t.Run(tt.name+"/"+stack.name+"/"+variant, func(t *testing.T) {
+					var (
+						out, errOut bytes.Buffer
+						exitCodes   []int
+						l           = timber.New(
+							timber.WithOut(&out),
+							timber.WithErrOut(&errOut),
+							timber.WithDisplayTime(false),
+							timber.WithShowErrorStack(stack.errorStack),
+							timber.WithShowFatalStack(stack.fatalStack),
+							timber.WithDurationFormatter(func(time.Duration) string { return "took" }),
+							timber.WithExitFunc(func(code int) { exitCodes = append(exitCodes, code) }),
+						)
+					)
+					if variant == "method" {
+						tt.method(l)
+					} else {
+						defer timber.SetDefault(l)()
+						tt.global()
+					}
+
+					written, other := out.String(), errOut.String()
+					if tt.severity >= timber.SeverityError {
+						written, other = other, written
+					}
+					if other != "" {
+						t.Errorf("wrote to the wrong output: %q", other)
+					}
+					if want := tt.level + " msg"; !strings.HasPrefix(written, want) {
+						t.Errorf("output %q doesn't start with %q", written, want)
+					}
+					firstLine, _, _ := strings.Cut(written, "\n")
+					if got := strings.HasSuffix(firstLine, " (took)"); got != tt.timed {
+						t.Errorf("duration suffix = %v, want %v in %q", got, tt.timed, firstLine)
+					}
+					if got := strings.Contains(written, err.Error()); got != tt.withErr {
+						t.Errorf("error message shown = %v, want %v in %q", got, tt.withErr, written)
+					}
+
+					wantStack := (tt.severity == timber.SeverityError && stack.errorStack) ||
+						(tt.severity == timber.SeverityFatal && stack.fatalStack)
+					if got := strings.Contains(written, "\n1. "); got != wantStack {
+						t.Errorf("stack shown = %v, want %v in %q", got, wantStack, written)
+					}
+					if wantStack && !strings.Contains(written, "log_test.go") {
+						t.Errorf("stack doesn't start at the caller: %q", written)
+					}
+
+					if tt.severity == timber.SeverityFatal {
+						if len(exitCodes) != 1 || exitCodes[0] != 1 {
+							t.Errorf("exit codes = %v, want [1]", exitCodes)
+						}
+					} else if len(exitCodes) != 0 {
+						t.Errorf("exited with %v for a non-fatal level", exitCodes)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestFatalRunsHooksBeforeExit(t *testing.T) {
+	var calls []string
+	l := timber.New(
+		timber.WithOut(&bytes.Buffer{}),
+		timber.WithErrOut(&bytes.Buffer{}),
+		timber.WithOnFatal(func() { calls = append(calls, "hook") }),
+		timber.WithFatalExitCode(3),
+		timber.WithExitFunc(func(int) { calls = append(calls, "exit") }),
+	)
+	l.FatalMsg("msg")
+	if strings.Join(calls, ",") != "hook,exit" {
+		t.Errorf("calls = %v, want [hook exit]", calls)
+	}
+}
+
+func TestFatalExitsWhenFiltered(t *testing.T) {
+	var (
+		out      bytes.Buffer
+		exitCode = -1
+	)
+	l := timber.New(
+		timber.WithErrOut(&out),
+		timber.WithMinLevel(timber.SeverityFatal+1),
+		timber.WithFatalExitCode(3),
+		timber.WithExitFunc(func(code int) { exitCode = code }),
+	)
+	l.FatalMsg("msg")
+	if out.Len() != 0 {
+		t.Errorf("filtered fatal entry was written: %q", out.String())
+	}
+	if exitCode != 3 {
+		t.Errorf("exit code = %d, want 3", exitCode)
+	}
+}
+
+func TestLogfmtStackIsOneValue(t *testing.T) {
+	var out bytes.Buffer
+	l := timber.New(
+		timber.WithErrOut(&out),
+		timber.WithFormat(timber.Logfmt),
+		timber.WithDisplayTime(false),
+	)
+	// logged from a closure so that the stack has more than one frame
+	func() {
+		l.Error(errors.New("something broke"), "msg", timber.Group("http", timber.A("method", "GET")))
+	}()
+
+	line, rest, _ := strings.Cut(out.String(), "\n")
+	if rest != "" {
+		t.Errorf("entry was written on more than one line: %q", out.String())
+	}
+	want := `level=ERROR msg=msg error="something broke" http.method=GET stack="1. `
+	if !strings.HasPrefix(line, want) {
+		t.Errorf("line = %q, want prefix %q", line, want)
+	}
+	if !strings.Contains(line, `log_test.go`) || !strings.Contains(line, `\n2. `) {
+		t.Errorf("stack isn't escaped into the value: %q", line)
+	}
+}
+
+func TestStackMaxDepth(t *testing.T) {
+	tests := []struct {
+		name   string
+		format timber.OutputFormat
+		check  func(t *testing.T, out string)
+	}{
+		{"plain", timber.Plain, func(t *testing.T, out string) {
+			if !strings.Contains(out, "\n2. ") || strings.Contains(out, "\n3. ") {
+				t.Errorf("stack doesn't have 2 frames: %q", out)
+			}
+			if !strings.Contains(out, "\n... 4 more\n") {
+				t.Errorf("stack doesn't say how many frames were left out: %q", out)
+			}
+		}},
+		{"logfmt", timber.Logfmt, func(t *testing.T, out string) {
+			if !strings.Contains(out, `\n2. `) || strings.Contains(out, `\n3. `) {
+				t.Errorf("stack doesn't have 2 frames: %q", out)
+			}
+			if !strings.HasSuffix(out, `\n... 4 more"`+"\n") {
+				t.Errorf("stack doesn't say how many frames were left out: %q", out)
+			}
+		}},
+		{"json", timber.JSON, func(t *testing.T, out string) {
+			var decoded struct {
+				Stack     []map[string]any `json:"stack"`
+				Truncated int              `json:"stack.truncated"`
+			}
+			if err := json.Unmarshal([]byte(out), &decoded); err != nil {
+				t.Fatal(err)
+			}
+			if len(decoded.Stack) != 2 || decoded.Truncated != 4 {
+				t.Errorf("stack has %d frames and %d truncated, want 2 and 4: %s",
+					len(decoded.Stack), decoded.Truncated, out)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			l := timber.New(
+				timber.WithErrOut(&out),
+				timber.WithFormat(tt.format),
+				timber.WithDisplayTime(false),
+				timber.WithStackTraceOptions(timber.StackOptions{MaxDepth: 2, ShowRecursion: true}),
+			)
+			// 5 recursive frames and the test function make 6 frames
+			var recurse func(n int)
+			recurse = func(n int) {
+				if n == 0 {
+					l.ErrorMsg("msg")
+					return
+				}
+				recurse(n - 1)
+			}
+			recurse(4)
+			tt.check(t, out.String())
+		})
+	}
+}
+
+// panicOnceWriter panics on its first write and then writes to the buffer
+type panicOnceWriter struct {
+	panicked bool
+	bytes.Buffer
+}
+
+func (w *panicOnceWriter) Write(p []byte) (int, error) {
+	if !w.panicked {
+		w.panicked = true
+		panic("write failed")
+	}
+	return w.Buffer.Write(p)
+}
+
+func TestPanickingWriterReleasesLocks(t *testing.T) {
+	var (
+		w = &panicOnceWriter{}
+		l = timber.New(timber.WithOut(w), timber.WithDisplayTime(false))
+	)
+	func() {
+		defer func() { _ = recover() }()
+		l.Info("first")
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		l.SetMinLevel(timber.SeverityInfo)
+		l.Info("second")
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("logger is still locked after a writer panicked")
+	}
+	if got := w.String(); got != "INFO  second\n" {
+		t.Errorf("output = %q, want the entry logged after the panic", got)
+	}
+}
+
+func TestLogAboveFatalDoesNotExit(t *testing.T) {
+	var (
+		out       bytes.Buffer
+		exitCodes []int
+	)
+	l := timber.New(
+		timber.WithErrOut(&out),
+		timber.WithDisplayTime(false),
+		timber.WithShowFatalStack(false),
+		timber.WithExitFunc(func(code int) { exitCodes = append(exitCodes, code) }),
+	)
+	l.Log(timber.SeverityFatal+5, "msg")
+	if got := out.String(); got != "FATAL msg\n" {
+		t.Errorf("output = %q, want the entry at the FATAL level", got)
+	}
+	if len(exitCodes) != 0 {
+		t.Errorf("exited with %v for a severity above FATAL", exitCodes)
+	}
+}
+
+func TestDefineLevelShowStack(t *testing.T) {
+	for _, severity := range []timber.Severity{timber.SeverityError, timber.SeverityFatal} {
+		var out bytes.Buffer
+		l := timber.New(
+			timber.WithErrOut(&out),
+			timber.WithDisplayTime(false),
+			timber.WithExitFunc(func(int) {}),
+			timber.WithDefinedLevel(timber.LevelDefinition{Severity: severity, Name: "BAD", ShowStack: false}),
+		)
+		l.Log(severity, "msg")
+		if got := out.String(); got != "BAD   msg\n" {
+			t.Errorf("output at %d = %q, want the entry without a stack", severity, got)
+		}
+	}
+}
@@ -2,58 +2,105 @@ package timber
 
 import "time"
 
+// Output a DEBUG-level message
+func (l *Logger) Debug(msg string, attrs ...Attr) {
+	l.log(SeverityDebug, time.Time{}, nil, msg, attrs)
+}
+
+// Output a DEBUG-level message since a certain time
+func (l *Logger) DebugSince(start time.Time, msg string, attrs ...Attr) {
+	l.log(SeverityDebug, start, nil, msg, attrs)
+}
+
+// Output a DONE-level message
+func (l *Logger) Done(msg string, attrs ...Attr) {
+	l.log(SeverityDone, time.Time{}, nil, msg, attrs)
+}
+
+// Output a DONE-level message since a certain time
+func (l *Logger) DoneSince(start time.Time, msg string, attrs ...Attr) {
+	l.log(SeverityDone, start, nil, msg, attrs)
+}
+
+// Output a INFO-level message
+func (l *Logger) Info(msg string, attrs ...Attr) {
+	l.log(SeverityInfo, time.Time{}, nil, msg, attrs)
+}
+
+// Output a INFO-level message since a certain time
+func (l *Logger) InfoSince(start time.Time, msg string, attrs ...Attr) {
+	l.log(SeverityInfo, start, nil, msg, attrs)
+}
+
+// Output a WARN-level message
+func (l *Logger) Warning(msg string, attrs ...Attr) {
+	l.log(SeverityWarning, time.Time{}, nil, msg, attrs)
+}
+
+// Output a WARN-level message since a certain time
+func (l *Logger) WarningSince(start time.Time, msg string, attrs ...Attr) {
+	l.log(SeverityWarning, start, nil, msg, attrs)
+}
+
+// Output a message at the level defined for severity. Severities without a
+// defined level are logged at the closest defined level below them. Only
+// SeverityFatal itself exits the program, not severities logged at the FATAL
+// level because they are above it.
+func (l *Logger) Log(severity Severity, msg string, attrs ...Attr) {
+	if severity == SeverityFatal {
+		l.log(severity, time.Time{}, nil, msg, attrs)
+		return
+	}
+	l.mutex.RLock()
+	severity = l.definedSeverity(severity)
+	l.mutex.RUnlock()
+	l.output(severity, time.Time{}, nil, msg, attrs)
+}
+
 // Output a DEBUG-level message
 func Debug(msg string, attrs ...Attr) {
-	globalLogger.mutex.RLock()
-	defer globalLogger.mutex.RUnlock()
-	logNormal(globalLogger.levels.Debug, msg, attrs)
+	globalLogger.Debug(msg, attrs...)
 }
 
 // Output a DEBUG-level message since a certain time
 func DebugSince(start time.Time, msg string, attrs ...Attr) {
-	globalLogger.mutex.RLock()
-	defer globalLogger.mutex.RUnlock()
-	logDurationNormal(globalLogger.levels.Debug, start, msg, attrs)
+	globalLogger.DebugSince(start, msg, attrs...)
 }
 
 // Output a DONE-level message
 func Done(msg string, attrs ...Attr) {
-	globalLogger.mutex.RLock()
-	defer globalLogger.mutex.RUnlock()
-	logNormal(globalLogger.levels.Done, msg, attrs)
+	globalLogger.Done(msg, attrs...)
 }
 
 // Output a DONE-level message since a certain time
 func DoneSince(start time.Time, msg string, attrs ...Attr) {
-	globalLogger.mutex.RLock()
-	defer globalLogger.mutex.RUnlock()
-	logDurationNormal(globalLogger.levels.Done, start, msg, attrs)
+	globalLogger.DoneSince(start, msg, attrs...)
 }
 
 // Output a INFO-level message
 func Info(msg string, attrs ...Attr) {
-	globalLogger.mutex.RLock()
-	defer globalLogger.mutex.RUnlock()
-	logNormal(globalLogger.levels.Info, msg, attrs)
+	globalLogger.Info(msg, attrs...)
 }
 
 // Output a INFO-level message since a certain time
 func InfoSince(start time.Time, msg string, attrs ...Attr) {
-	globalLogger.mutex.RLock()
-	defer globalLogger.mutex.RUnlock()
-	logDurationNormal(globalLogger.levels.Info, start, msg, attrs)
+	globalLogger.InfoSince(start, msg, attrs...)
 }
 
 // Output a WARN-level message
 func Warning(msg string, attrs ...Attr) {
-	globalLogger.mutex.RLock()
-	defer globalLogger.mutex.RUnlock()
-	logNormal(globalLogger.levels.Warning, msg, attrs)
+	globalLogger.Warning(msg, attrs...)
 }
 
-// Output a WARNING-level message since a certain time
+// Output a WARN-level message since a certain time
 func WarningSince(start time.Time, msg string, attrs ...Attr) {
-	globalLogger.mutex.RLock()
-	defer globalLogger.mutex.RUnlock()
-	logDurationNormal(globalLogger.levels.Done, start, msg, attrs)
+	globalLogger.WarningSince(start, msg, attrs...)
+}
+
+// Output a message at the level defined for severity. Severities without a
+// defined level are logged at the closest defined level below them. Only
+// SeverityFatal itself exits the program, not severities logged at the FATAL
+// level because they are above it.
+func Log(severity Severity, msg string, attrs ...Attr) {
+	globalLogger.Log(severity, msg, attrs...)
 }
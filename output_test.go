@@ -0,0 +1,138 @@
+package timber_test
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"go.mattglei.ch/timber"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+type stringer struct{}
+
+func (stringer) String() string { return "i am a stringer" }
+
+// TestOutputGolden checks that every format writes exactly the same bytes as
+// it did when the golden file was generated
+func TestOutputGolden(t *testing.T) {
+	attrs := []timber.Attr{
+		timber.A("s", "hello"),
+		timber.A("space", "has space"),
+		timber.A("empty", ""),
+		timber.A("escaped", "a\"b\\c\nd\te\x01\x7f"),
+		timber.A("unicode", "héllo ✓"),
+		timber.A("invalid", "a\xffb"),
+		timber.A("html", " x<>&"),
+		timber.A("int", 42),
+		timber.A("int8", int8(-8)),
+		timber.A("int64", int64(1<<62)),
+		timber.A("uint8", uint8(200)),
+		timber.A("uint64", uint64(math.MaxUint64)),
+		timber.A("bool", true),
+		timber.A("float", 3.14),
+		timber.A("whole", 1.0),
+		timber.A("big", 1e21),
+		timber.A("small", 1e-7),
+		timber.A("float32", float32(0.1)),
+		timber.A("inf", math.Inf(1)),
+		timber.A("nan", math.NaN()),
+		timber.A("duration", 1500*time.Millisecond),
+		timber.A("time", time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)),
+		timber.A("err", errors.New("bad thing")),
+		timber.A("nil", nil),
+		timber.A("nil error", (*nilError)(nil)),
+		timber.A("nil time", (*time.Time)(nil)),
+		timber.A("nil stringer", (*stringer)(nil)),
+		timber.A("stringer", stringer{}),
+		timber.A("slice", []int{1, 2}),
+		timber.A("map", map[string]int{"a": 1}),
+		timber.A("struct", struct{ X, Y int }{1, 2}),
+		timber.A("bytes", []byte("raw")),
+		timber.Group("g", timber.A("a", 1), timber.A("b", "x y"), timber.Group("h", timber.A("c", 2.5))),
+		timber.A("bad key=\"", "v"),
+	}
+	wrapped := fmt.Errorf("outer: %w", errors.Join(errors.New("one"), fmt.Errorf("two: %w", errors.New("three"))))
+
+	var out bytes.Buffer
+	for _, format := range []timber.OutputFormat{timber.Plain, timber.Logfmt, timber.JSON} {
+		l := timber.New(
+			timber.WithOut(&out),
+			timber.WithErrOut(&out),
+			timber.WithFormat(format),
+			timber.WithDisplayTime(false),
+			timber.WithShowErrorStack(false),
+			timber.WithDurationFormatter(func(time.Duration) string { return "1.5s" }),
+		)
+		l.Info("all values", attrs...)
+		l.Info("no attrs")
+		l.Info("msg with \"quotes\" and\nnewline")
+		l.DoneSince(time.Now(), "timed", timber.A("k", 1))
+		l.Warning("warn")
+		l.Debug("debug", timber.A("k", "v"))
+		bound := l.With(timber.A("req", "a b"), timber.A("n", 1))
+		bound.Info("bound")
+		bound.Info("bound with attrs", timber.A("x", 2.5))
+		l.Error(wrapped, "failed", timber.A("k", "v"))
+		l.ErrorMsg("msg only")
+	}
+
+	golden := "testdata/output.golden"
+	if *update {
+		if err := os.WriteFile(golden, out.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out.Bytes(), want) {
+		t.Errorf("output doesn't match %s, run go test -update if the change is intended\ngot:\n%s\nwant:\n%s", golden, out.Bytes(), want)
+	}
+}
+
+func BenchmarkInfoPlain(b *testing.B) {
+	benchmarkInfo(b, timber.Plain)
+}
+
+func BenchmarkInfoLogfmt(b *testing.B) {
+	benchmarkInfo(b, timber.Logfmt)
+}
+
+func BenchmarkInfoJSON(b *testing.B) {
+	benchmarkInfo(b, timber.JSON)
+}
+
+func benchmarkInfo(b *testing.B, format timber.OutputFormat) {
+	l := timber.New(timber.WithOut(io.Discard), timber.WithFormat(format))
+	b.ReportAllocs()
+	for b.Loop() {
+		l.Info("handled request", timber.A("method", "GET"), timber.A("status", 200), timber.A("path", "/users"))
+	}
+}
+
+type nilError struct{}
+
+func (*nilError) Error() string { return "nil error" }
+
+func TestJSONTypedNilPointers(t *testing.T) {
+	var out bytes.Buffer
+	l := timber.New(timber.WithOut(&out), timber.WithFormat(timber.JSON), timber.WithDisplayTime(false))
+	l.Info("msg",
+		timber.A("err", (*nilError)(nil)),
+		timber.A("time", (*time.Time)(nil)),
+		timber.A("stringer", (*stringer)(nil)),
+	)
+	want := `{"level":"INFO","msg":"msg","err":null,"time":null,"stringer":null}` + "\n"
+	if got := out.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
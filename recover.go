@@ -0,0 +1,143 @@
+package timber
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// PanicAction is what happens after Recover or Go logs a panic
+type PanicAction int
+
+const (
+	// Log the panic at the ERROR level and then panic again with the same value
+	PanicRepanic PanicAction = iota
+	// Log the panic at the FATAL level and then exit with the fatal exit code
+	PanicExit
+	// Log the panic at the ERROR level and then continue
+	PanicSwallow
+)
+
+// panicError holds a recovered panic value along with the stack of where the
+// panic happened
+type panicError struct {
+	value  any
+	frames []Frame
+}
+
+func (e *panicError) Error() string {
+	if err, ok := e.value.(error); ok {
+		return err.Error()
+	}
+	return fmt.Sprint(e.value)
+}
+
+func (e *panicError) Unwrap() error {
+	err, _ := e.value.(error)
+	return err
+}
+
+func (e *panicError) Frames() []Frame {
+	return e.frames
+}
+
+// panicStack returns the stack of the function that panicked. It must be called
+// from a deferred function while panicking.
+func panicStack() []Frame {
+	frames := CaptureStack(1)
+	for i, f := range frames {
+		if f.Function != "runtime.gopanic" {
+			continue
+		}
+		stack := frames[i+1:]
+		// skip runtime frames for panics caused by runtime errors
+		for len(stack) != 0 && strings.HasPrefix(stack[0].Function, "runtime.") {
+			stack = stack[1:]
+		}
+		// remove the goroutine wrapper from Go
+		return slices.DeleteFunc(stack, func(f Frame) bool {
+			return strings.HasPrefix(f.Function, packagePrefix)
+		})
+	}
+	return nil
+}
+
+func (l *Logger) handlePanic(value any, msg string, attrs []Attr) {
+	err := &panicError{value: value, frames: panicStack()}
+
+	l.mutex.RLock()
+	action := l.panicAction
+	l.mutex.RUnlock()
+
+	severity := SeverityError
+	if action == PanicExit {
+		severity = SeverityFatal
+	}
+	l.log(severity, time.Time{}, err, msg, attrs)
+	if action == PanicRepanic {
+		panic(value)
+	}
+}
+
+// Recover logs a panic with the stack of where it happened. It must be called
+// directly with defer:
+//
+//	defer logger.Recover("failed to handle request")
+//
+// What happens after the panic is logged is set with OnPanic.
+func (l *Logger) Recover(msg string, attrs ...Attr) {
+	if r := recover(); r != nil {
+		l.handlePanic(r, msg, attrs)
+	}
+}
+
+// Go runs fn in a new goroutine and logs any panic that happens in it
+func (l *Logger) Go(fn func()) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				l.handlePanic(r, "panic in goroutine", nil)
+			}
+		}()
+		fn()
+	}()
+}
+
+// Set what happens after Recover or Go logs a panic.
+//
+// Default is PanicRepanic
+func (l *Logger) OnPanic(action PanicAction) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.panicAction = action
+}
+
+// Recover logs a panic with the stack of where it happened. It must be called
+// directly with defer:
+//
+//	defer timber.Recover("failed to handle request")
+//
+// What happens after the panic is logged is set with OnPanic.
+func Recover(msg string, attrs ...Attr) {
+	if r := recover(); r != nil {
+		globalLogger.handlePanic(r, msg, attrs)
+	}
+}
+
+// Go runs fn in a new goroutine and logs any panic that happens in it
+func Go(fn func()) {
+	globalLogger.Go(fn)
+}
+
+// Set what happens after Recover or Go logs a panic.
+//
+// Default is PanicRepanic
+func OnPanic(action PanicAction) {
+	globalLogger.OnPanic(action)
+}
+
+// Option to set what happens after Recover or Go logs a panic.
+func WithOnPanic(action PanicAction) Option {
+	return func(l *Logger) { l.OnPanic(action) }
+}
@@ -0,0 +1,130 @@
+package timber
+
+import (
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// Routes maps each severity to the writers that entries at that severity are
+// written to
+type Routes map[Severity][]io.Writer
+
+// route sets the writers for a severity. Routes are copied first since they can
+// be shared with loggers created with With.
+func (l *Logger) route(severity Severity, writers ...io.Writer) {
+	routes := maps.Clone(l.routes)
+	if routes == nil {
+		routes = Routes{}
+	}
+	if len(writers) == 0 {
+		delete(routes, severity)
+	} else {
+		routes[severity] = slices.Clone(writers)
+	}
+	l.routes = routes
+}
+
+// routeSinks replaces the sinks created from the routing table. Each writer gets
+// a single sink for all of the severities routed to it so that levels are
+// styled for that writer's color profile.
+func (l *Logger) routeSinks() {
+	var routed []Sink
+	for _, severity := range slices.Sorted(maps.Keys(l.routes)) {
+		for _, w := range l.routes[severity] {
+			i := slices.IndexFunc(routed, func(s Sink) bool { return sameWriter(s.Writer, w) })
+			if i == -1 {
+				routed = append(routed, Sink{Writer: w, Levels: []Severity{}, Format: l.format})
+				i = len(routed) - 1
+			}
+			if !slices.Contains(routed[i].Levels, severity) {
+				routed[i].Levels = append(routed[i].Levels, severity)
+			}
+		}
+	}
+
+	sinks := make([]sink, 0, len(routed)+len(l.sinks))
+	for _, s := range routed {
+		sinks = append(sinks, l.newSink(s, true))
+	}
+	for _, s := range l.sinks {
+		if !s.routed {
+			sinks = append(sinks, s)
+		}
+	}
+	l.sinks = sinks
+}
+
+// sameWriter checks if two writers are the same without panicking on writers
+// that can't be compared
+func sameWriter(a, b io.Writer) bool {
+	if a == nil || b == nil || reflect.TypeOf(a) != reflect.TypeOf(b) ||
+		!reflect.TypeOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
+
+// Route entries at the given severity to the writers, replacing the writers it
+// was routed to before. No writers stops the severity from being written
+// anywhere other than added sinks.
+func (l *Logger) Route(severity Severity, writers ...io.Writer) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.route(severity, writers...)
+	l.routeSinks()
+}
+
+// Set the routing table that maps each severity to the writers it is written to.
+//
+// Default routes DEBUG through WARN to os.Stdout and ERROR and FATAL to os.Stderr
+func (l *Logger) SetRoutes(routes Routes) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.routes = Routes{}
+	for severity, writers := range routes {
+		l.route(severity, writers...)
+	}
+	l.routeSinks()
+}
+
+// Get the routing table that maps each severity to the writers it is written to
+func (l *Logger) GetRoutes() Routes {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	routes := make(Routes, len(l.routes))
+	for severity, writers := range l.routes {
+		routes[severity] = slices.Clone(writers)
+	}
+	return routes
+}
+
+// Route entries at the given severity to the writers, replacing the writers it
+// was routed to before. No writers stops the severity from being written
+// anywhere other than added sinks.
+func Route(severity Severity, writers ...io.Writer) {
+	globalLogger.Route(severity, writers...)
+}
+
+// Set the routing table that maps each severity to the writers it is written to.
+//
+// Default routes DEBUG through WARN to os.Stdout and ERROR and FATAL to os.Stderr
+func SetRoutes(routes Routes) {
+	globalLogger.SetRoutes(routes)
+}
+
+// Get the routing table that maps each severity to the writers it is written to
+func GetRoutes() Routes {
+	return globalLogger.GetRoutes()
+}
+
+// Option to route entries at the given severity to the writers.
+func WithRoute(severity Severity, writers ...io.Writer) Option {
+	return func(l *Logger) { l.Route(severity, writers...) }
+}
+
+// Option to set the routing table that maps each severity to its writers.
+func WithRoutes(routes Routes) Option {
+	return func(l *Logger) { l.SetRoutes(routes) }
+}
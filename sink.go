@@ -0,0 +1,142 @@
+package timber
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Sink is a destination that log entries are written to. Every entry is written
+// to each sink whose level range includes the entry's severity.
+type Sink struct {
+	Writer io.Writer
+	// Minimum severity written to the sink. 0 writes every level.
+	MinLevel Severity
+	// Maximum severity written to the sink. 0 has no maximum.
+	MaxLevel Severity
+	// Only write these severities to the sink. MinLevel and MaxLevel are ignored
+	// when this is set.
+	Levels []Severity
+	Format OutputFormat
+
+	// encodes entries for sinks that don't write lines in one of the formats
+	encode func(l *Logger, b []byte, e entry) []byte
+}
+
+// sink is a Sink along with the state needed to write to it
+type sink struct {
+	Sink
+	// created from the routing table rather than added as a sink
+	routed bool
+	// serializes writes to the writer, shared with loggers created with With
+	mutex    *sync.Mutex
+	renderer *lipgloss.Renderer
+	// level messages rendered for the color profile of the writer
+	levels map[Severity]string
+}
+
+// asciiRenderer renders text without any colors or styles
+var asciiRenderer = func() *lipgloss.Renderer {
+	renderer := lipgloss.NewRenderer(io.Discard)
+	renderer.SetColorProfile(termenv.Ascii)
+	return renderer
+}()
+
+func (l *Logger) newSink(s Sink, routed bool) sink {
+	renderer := lipgloss.NewRenderer(s.Writer)
+	if s.Format == PlainNoColor {
+		renderer.SetColorProfile(termenv.Ascii)
+	}
+	out := sink{Sink: s, routed: routed, mutex: &sync.Mutex{}, renderer: renderer}
+	out.render(l.levels)
+	return out
+}
+
+// write b to the sink's writer, one entry at a time
+func (s *sink) write(b []byte) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	_, _ = s.Writer.Write(b)
+}
+
+func (s *sink) render(levels map[Severity]definedLevel) {
+	s.levels = make(map[Severity]string, len(levels))
+	for severity, level := range levels {
+		s.levels[severity] = renderLevel(level.Level, s.renderer)
+	}
+}
+
+func renderLevel(level Level, renderer *lipgloss.Renderer) string {
+	return level.Style.Renderer(renderer).Render(fmt.Sprintf("%-5s", level.Message))
+}
+
+func (s *sink) accepts(severity Severity) bool {
+	if s.Levels != nil {
+		return slices.Contains(s.Levels, severity)
+	}
+	return severity >= s.MinLevel && (s.MaxLevel == 0 || severity <= s.MaxLevel)
+}
+
+// renderLevels renders the level messages for every sink
+func (l *Logger) renderLevels() {
+	l.sinks = slices.Clone(l.sinks)
+	for i := range l.sinks {
+		l.sinks[i].render(l.levels)
+	}
+}
+
+func (l *Logger) writers() []io.Writer {
+	writers := make([]io.Writer, 0, len(l.sinks))
+	for _, s := range l.sinks {
+		writers = append(writers, s.Writer)
+	}
+	return writers
+}
+
+// Add a sink that entries are written to along with the existing sinks.
+func (l *Logger) AddSink(s Sink) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.sinks = append(slices.Clip(l.sinks), l.newSink(s, false))
+}
+
+// Set the sinks that entries are written to, replacing every existing sink
+// including the ones from the routing table, which is cleared.
+//
+// Default is os.Stdout for DEBUG through WARN and os.Stderr for ERROR and FATAL
+func (l *Logger) SetSinks(sinks ...Sink) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.routes = Routes{}
+	l.sinks = make([]sink, 0, len(sinks))
+	for _, s := range sinks {
+		l.sinks = append(l.sinks, l.newSink(s, false))
+	}
+}
+
+// Add a sink that entries are written to along with the existing sinks.
+func AddSink(s Sink) {
+	globalLogger.AddSink(s)
+}
+
+// Set the sinks that entries are written to, replacing every existing sink
+// including the ones from the routing table, which is cleared.
+//
+// Default is os.Stdout for DEBUG through WARN and os.Stderr for ERROR and FATAL
+func SetSinks(sinks ...Sink) {
+	globalLogger.SetSinks(sinks...)
+}
+
+// Option to add a sink that entries are written to.
+func WithSink(s Sink) Option {
+	return func(l *Logger) { l.AddSink(s) }
+}
+
+// Option to set the sinks that entries are written to, replacing the routed ones.
+func WithSinks(sinks ...Sink) Option {
+	return func(l *Logger) { l.SetSinks(sinks...) }
+}
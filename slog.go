@@ -0,0 +1,149 @@
+package timber
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// SlogLevelDone is the slog level that is logged at timber's DONE level. It sits
+// between slog.LevelInfo and slog.LevelWarn.
+const SlogLevelDone = slog.Level(2)
+
+// SlogHandler is a slog.Handler that writes records using a timber Logger
+type SlogHandler struct {
+	logger *Logger
+	// attributes bound with WithAttrs, split by the groups opened with
+	// WithGroup. The first has no group.
+	groups []slogGroup
+}
+
+type slogGroup struct {
+	name  string
+	attrs []Attr
+}
+
+// NewSlogHandler creates a slog.Handler that writes records with the given
+// logger. If logger is nil the default logger is used.
+//
+// slog levels are mapped onto timber levels as follows:
+// below slog.LevelInfo   - DEBUG
+// below SlogLevelDone    - INFO
+// below slog.LevelWarn   - DONE
+// below slog.LevelError  - WARN
+// slog.LevelError and up - ERROR
+func NewSlogHandler(logger *Logger) *SlogHandler {
+	if logger == nil {
+		logger = globalLogger
+	}
+	return &SlogHandler{logger: logger, groups: []slogGroup{{}}}
+}
+
+func slogSeverity(level slog.Level) Severity {
+	switch {
+	case level < slog.LevelInfo:
+		return SeverityDebug
+	case level < SlogLevelDone:
+		return SeverityInfo
+	case level < slog.LevelWarn:
+		return SeverityDone
+	case level < slog.LevelError:
+		return SeverityWarning
+	default:
+		return SeverityError
+	}
+}
+
+// Enabled reports whether the logger's minimum level allows records at level
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.Enabled(slogSeverity(level))
+}
+
+// Handle writes the record with timber's formatting
+func (h *SlogHandler) Handle(_ context.Context, record slog.Record) error {
+	attrs := make([]Attr, 0, record.NumAttrs())
+	record.Attrs(func(a slog.Attr) bool {
+		attrs = appendSlogAttr(attrs, a)
+		return true
+	})
+	// nest the record's attributes in the open groups from the innermost out
+	for i := len(h.groups) - 1; i >= 0; i-- {
+		attrs = append(slices.Clip(h.groups[i].attrs), attrs...)
+		if i != 0 && len(attrs) != 0 {
+			attrs = []Attr{Group(h.groups[i].name, attrs...)}
+		}
+	}
+
+	l := h.logger
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	severity := slogSeverity(record.Level)
+	if !l.enabled(severity) {
+		return nil
+	}
+	// the stack trace would only show slog internals so it is never shown. A zero
+	// time is kept so that the time is left out as slog.Handler requires.
+	e := entry{
+		severity: severity,
+		level:    l.level(severity),
+		time:     record.Time,
+		msg:      record.Message,
+		attrs:    attrs,
+	}
+	if l.callers.enabled {
+		if c, ok := callerFromPC(record.PC); ok {
+			e.caller = &c
+		}
+	}
+	l.write(e)
+	return nil
+}
+
+// WithAttrs returns a handler that includes the given attributes in every record
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	groups := slices.Clone(h.groups)
+	last := &groups[len(groups)-1]
+	for _, a := range attrs {
+		last.attrs = appendSlogAttr(slices.Clip(last.attrs), a)
+	}
+	return &SlogHandler{logger: h.logger, groups: groups}
+}
+
+// WithGroup returns a handler that nests all following attributes in a group
+// with the given name
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &SlogHandler{
+		logger: h.logger,
+		groups: append(slices.Clip(h.groups), slogGroup{name: name}),
+	}
+}
+
+// convert a slog attribute into timber attributes
+func appendSlogAttr(attrs []Attr, a slog.Attr) []Attr {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return attrs
+	}
+	if a.Value.Kind() != slog.KindGroup {
+		return append(attrs, Attr{Key: a.Key, Value: a.Value.Any()})
+	}
+	var groupAttrs []Attr
+	for _, ga := range a.Value.Group() {
+		groupAttrs = appendSlogAttr(groupAttrs, ga)
+	}
+	switch {
+	case len(groupAttrs) == 0:
+		return attrs
+	case a.Key == "":
+		// groups without a key are inlined
+		return append(attrs, groupAttrs...)
+	default:
+		return append(attrs, Group(a.Key, groupAttrs...))
+	}
+}
@@ -0,0 +1,61 @@
+package timber_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"testing/slogtest"
+
+	"go.mattglei.ch/timber"
+)
+
+func TestSlogHandler(t *testing.T) {
+	var out bytes.Buffer
+	l := timber.New(
+		timber.WithOut(&out),
+		timber.WithErrOut(&out),
+		timber.WithFormat(timber.JSON),
+	)
+	results := func() []map[string]any {
+		var entries []map[string]any
+		for line := range strings.Lines(out.String()) {
+			var entry map[string]any
+			if err := json.Unmarshal([]byte(line), &entry); err != nil {
+				t.Fatal(err)
+			}
+			entries = append(entries, entry)
+		}
+		return entries
+	}
+	if err := slogtest.TestHandler(timber.NewSlogHandler(l), results); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSlogGroupsMatchTimberGroups(t *testing.T) {
+	for _, format := range []timber.OutputFormat{timber.Plain, timber.Logfmt, timber.JSON} {
+		var slogOut, timberOut bytes.Buffer
+		newLogger := func(out *bytes.Buffer) *timber.Logger {
+			return timber.New(timber.WithOut(out), timber.WithFormat(format), timber.WithDisplayTime(false))
+		}
+
+		logger := slog.New(timber.NewSlogHandler(newLogger(&slogOut)))
+		logger.With("id", 1).WithGroup("http").With("method", "GET").
+			Info("handled", "status", 200, slog.Group("timing", "ms", 5))
+
+		newLogger(&timberOut).Info("handled",
+			timber.A("id", 1),
+			timber.Group("http",
+				timber.A("method", "GET"),
+				timber.A("status", int64(200)),
+				timber.Group("timing", timber.A("ms", int64(5))),
+			),
+		)
+
+		if slogOut.String() != timberOut.String() {
+			t.Errorf("slog output = %q, want %q", slogOut.String(), timberOut.String())
+		}
+	}
+}
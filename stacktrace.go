@@ -1,61 +1,290 @@
 package timber
 
 import (
-	"fmt"
+	"path"
+	"reflect"
+	"runtime"
 	"runtime/debug"
+	"strconv"
 	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
-type frame struct {
+// maximum number of frames collected for a stack trace
+const maxStackDepth = 64
+
+// Frame is a single function call in a stack trace
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+	PC       uintptr
+}
+
+// StackOptions controls which frames are shown in stack traces and how they are
+// displayed. The zero value hides standard library frames and collapses
+// recursive calls.
+type StackOptions struct {
+	// Show frames from the standard library and runtime
+	ShowStdlib bool
+	// Hide frames from packages whose import path starts with any of these prefixes
+	ExcludePackages []string
+	// Maximum number of frames to show. 0 shows every frame. Plain and logfmt
+	// output end with how many frames were left out and JSON output has them in
+	// a stack.truncated field.
+	MaxDepth int
+	// Trim file paths so they are relative to the root of their module or GOROOT
+	TrimPaths bool
+	// Show every frame of a recursive call instead of collapsing them into one
+	ShowRecursion bool
+}
+
+// a frame that is ready to be rendered
+type stackFrame struct {
 	function string
 	path     string
+	repeated int
 }
 
-func framesFromCall(skip int) []frame {
-	frames := []frame{}
-	out := string(debug.Stack())
-	lines := strings.Split(out, "\n")
+// CaptureStack returns the stack of the calling goroutine. skip is the number of
+// frames to skip with 0 being the function that called CaptureStack.
+func CaptureStack(skip int) []Frame {
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(skip+2, pcs[:])
+	return framesFromPCs(pcs[:n])
+}
 
-	// skip first line as that is information about the current goroutine that we do not care about
-	for i := 1 + (skip * 2); i < len(lines)-1; i++ {
-		f := frame{}
-		function := lines[i]
-		parameterStart := strings.IndexRune(function, '(')
-		if parameterStart != -1 {
-			function = fmt.Sprintf("%s()", function[:parameterStart])
+// framesFromCall returns the stack starting at the first frame outside of timber
+func framesFromCall() []Frame {
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(2, pcs[:])
+	callersFrames := runtime.CallersFrames(pcs[:n])
+	frames := make([]Frame, 0, n)
+	for {
+		f, more := callersFrames.Next()
+		if len(frames) != 0 || !internalFrame(f.Function) {
+			frames = append(frames, newFrame(f))
+		}
+		if !more {
+			return frames
 		}
-		f.function = strings.TrimPrefix(function, "created by ")
+	}
+}
+
+func framesFromPCs(pcs []uintptr) []Frame {
+	if len(pcs) == 0 {
+		return nil
+	}
+	callersFrames := runtime.CallersFrames(pcs)
+	frames := make([]Frame, 0, len(pcs))
+	for {
+		f, more := callersFrames.Next()
+		frames = append(frames, newFrame(f))
+		if !more {
+			return frames
+		}
+	}
+}
 
-		pathParts := strings.Split(lines[i+1], " ")
-		f.path = strings.TrimSpace(strings.Join(pathParts[:len(pathParts)-1], " "))
-		frames = append(frames, f)
-		i++ // increase twice because we just processed 2 lines
+func newFrame(f runtime.Frame) Frame {
+	return Frame{
+		Function: f.Function,
+		File:     f.File,
+		Line:     f.Line,
+		PC:       f.PC,
 	}
+}
+
+// packagePath gets the import path of the package that a function belongs to
+func packagePath(function string) string {
+	lastSlash := strings.LastIndexByte(function, '/')
+	dot := strings.IndexByte(function[lastSlash+1:], '.')
+	if dot == -1 {
+		return function
+	}
+	return function[:lastSlash+1+dot]
+}
 
-	if len(frames) <= 1 {
-		return frames
+// isStdlib checks if a frame is part of the standard library by where its source
+// file is
+func isStdlib(f Frame) bool {
+	if root := goroot(); root != "" {
+		return strings.HasPrefix(f.File, root+"/src/")
 	}
-	// path for each frame should be shifted up 1
-	for i := 1; i < len(frames); i++ {
-		frames[i-1].path = frames[i].path
+	// binaries built with -trimpath don't record GOROOT so the frame is checked
+	// against the modules that the binary was built from instead
+	pkg := packagePath(f.Function)
+	if pkg == "main" {
+		return false
 	}
-	frames[len(frames)-1].path = ""
+	if modules := buildModules(); len(modules) != 0 {
+		for _, module := range modules {
+			if pkg == module || strings.HasPrefix(pkg, module+"/") {
+				return false
+			}
+		}
+		return true
+	}
+	// only standard library packages have import paths without a dot in their
+	// first element
+	first, _, _ := strings.Cut(pkg, "/")
+	return !strings.Contains(first, ".")
+}
 
-	return frames
+func (o StackOptions) hidden(f Frame) bool {
+	if !o.ShowStdlib && isStdlib(f) {
+		return true
+	}
+	pkg := packagePath(f.Function)
+	for _, prefix := range o.ExcludePackages {
+		if strings.HasPrefix(pkg, prefix) {
+			return true
+		}
+	}
+	return false
 }
 
-func stackTrace(out *string, skip int) {
-	frames := framesFromCall(skip)
+// filter, collapse, and limit frames based on the options, returning the frames
+// to show and the number of frames left out because of MaxDepth
+func (o StackOptions) apply(frames []Frame) ([]stackFrame, int) {
+	out := make([]stackFrame, 0, len(frames))
+	for _, f := range frames {
+		if o.hidden(f) {
+			continue
+		}
+		if !o.ShowRecursion && len(out) != 0 && out[len(out)-1].function == f.Function {
+			out[len(out)-1].repeated++
+			continue
+		}
+		p := ""
+		if f.File != "" {
+			file := f.File
+			if o.TrimPaths {
+				file = trimPath(file, f.Function)
+			}
+			p = file + ":" + strconv.Itoa(f.Line)
+		}
+		out = append(out, stackFrame{function: f.Function, path: p})
+	}
+	if o.MaxDepth > 0 && len(out) > o.MaxDepth {
+		return out[:o.MaxDepth], len(out) - o.MaxDepth
+	}
+	return out, 0
+}
+
+var buildPaths = sync.OnceValues(func() (string, string) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "", ""
+	}
+	return info.Main.Path, info.Path
+})
+
+// import paths of the main module and its dependencies
+var buildModules = sync.OnceValue(func() []string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	modules := make([]string, 0, len(info.Deps)+1)
+	if info.Main.Path != "" {
+		modules = append(modules, info.Main.Path)
+	}
+	for _, dep := range info.Deps {
+		modules = append(modules, dep.Path)
+	}
+	return modules
+})
+
+// find GOROOT from where the runtime's source files were when the binary was built
+var goroot = sync.OnceValue(func() string {
+	fn := runtime.FuncForPC(reflect.ValueOf(runtime.Gosched).Pointer())
+	if fn == nil {
+		return ""
+	}
+	file, _ := fn.FileLine(fn.Entry())
+	root, _, ok := strings.Cut(file, "/src/runtime/")
+	if !ok {
+		return ""
+	}
+	return root
+})
+
+// trimPath makes a file path relative to the root of its module or GOROOT
+func trimPath(file string, function string) string {
+	if root := goroot(); root != "" {
+		if rel, ok := strings.CutPrefix(file, root+"/src/"); ok {
+			return rel
+		}
+	}
+	// dependencies in the module cache already have their module path and version
+	// in the file path
+	if _, rel, ok := strings.Cut(file, "/pkg/mod/"); ok {
+		return rel
+	}
+
+	modulePath, mainPath := buildPaths()
+	if modulePath == "" {
+		return file
+	}
+	pkg := packagePath(function)
+	if pkg == "main" {
+		pkg = mainPath
+	}
+	pkgDir, ok := strings.CutPrefix(pkg, modulePath)
+	if !ok || (pkgDir != "" && pkgDir[0] != '/') {
+		return file
+	}
+	dir := path.Dir(file)
+	if root, ok := strings.CutSuffix(dir, pkgDir); ok {
+		return strings.TrimPrefix(file, root+"/")
+	}
+	return file
+}
+
+func (l *Logger) appendStackTrace(b []byte, frames []stackFrame, hidden int, renderer *lipgloss.Renderer) []byte {
 	if len(frames) != 0 {
-		*out += "\n"
+		b = append(b, '\n')
 	}
 	for i, f := range frames {
-		trace := fmt.Sprintf("%d. %s", i+1, f.function)
+		b = strconv.AppendInt(b, int64(i+1), 10)
+		b = append(b, ". "...)
+		b = append(b, f.function...)
+		b = append(b, "()"...)
+		if f.repeated != 0 {
+			b = append(b, " (repeated "...)
+			b = strconv.AppendInt(b, int64(f.repeated+1), 10)
+			b = append(b, " times)"...)
+		}
 		if f.path != "" {
-			trace = fmt.Sprintf("%s %s", trace, globalLogger.stackPathStyle.Render(
-				fmt.Sprintf("[%s]", f.path),
-			))
+			b = append(b, ' ')
+			b = append(b, l.stackPathStyle.Renderer(renderer).Render("["+f.path+"]")...)
 		}
-		*out += trace + "\n"
+		b = append(b, '\n')
 	}
+	if hidden != 0 {
+		b = append(b, "... "...)
+		b = strconv.AppendInt(b, int64(hidden), 10)
+		b = append(b, " more\n"...)
+	}
+	return b
+}
+
+// Set the options used to filter and display stack traces.
+func (l *Logger) StackTraceOptions(opts StackOptions) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.stack = opts
+}
+
+// Set the options used to filter and display stack traces.
+func StackTraceOptions(opts StackOptions) {
+	globalLogger.StackTraceOptions(opts)
+}
+
+// Option to set the options used to filter and display stack traces.
+func WithStackTraceOptions(opts StackOptions) Option {
+	return func(l *Logger) { l.StackTraceOptions(opts) }
 }
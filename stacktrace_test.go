@@ -0,0 +1,68 @@
+package timber
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+// debugStackFrames is the parser of debug.Stack output that stack traces were
+// built with before runtime.Callers, kept to compare against
+func debugStackFrames(skip int) []stackFrame {
+	frames := []stackFrame{}
+	lines := strings.Split(string(debug.Stack()), "\n")
+	for i := 1 + (skip * 2); i < len(lines)-1; i += 2 {
+		function := lines[i]
+		if parameterStart := strings.LastIndexByte(function, '('); parameterStart != -1 {
+			function = fmt.Sprintf("%s()", function[:parameterStart])
+		}
+		path := lines[i+1]
+		if offsetStart := strings.LastIndex(path, " +0x"); offsetStart != -1 {
+			path = path[:offsetStart]
+		}
+		frames = append(frames, stackFrame{
+			function: strings.TrimPrefix(function, "created by "),
+			path:     strings.TrimSpace(path),
+		})
+	}
+	for i := 1; i < len(frames); i++ {
+		frames[i-1].path = frames[i].path
+	}
+	return frames
+}
+
+func BenchmarkStackTrace(b *testing.B) {
+	opts := StackOptions{}
+	b.Run("Callers", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_, _ = opts.apply(framesFromCall())
+		}
+	})
+	b.Run("DebugStack", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = debugStackFrames(2)
+		}
+	})
+}
+
+func TestIsStdlib(t *testing.T) {
+	tests := []struct {
+		frame Frame
+		want  bool
+	}{
+		{Frame{Function: "net/http.HandlerFunc.ServeHTTP", File: goroot() + "/src/net/http/server.go"}, true},
+		{Frame{Function: "runtime.goexit", File: goroot() + "/src/runtime/asm_amd64.s"}, true},
+		// modules don't need a dot in their path
+		{Frame{Function: "myapp/svc.Handle", File: "/home/user/myapp/svc/svc.go"}, false},
+		{Frame{Function: "main.main", File: "/home/user/myapp/main.go"}, false},
+		{Frame{Function: "github.com/labstack/echo.(*Echo).ServeHTTP", File: "/go/pkg/mod/github.com/labstack/echo@v3.3.10/echo.go"}, false},
+	}
+	for _, tt := range tests {
+		if got := isStdlib(tt.frame); got != tt.want {
+			t.Errorf("isStdlib(%s) = %v, want %v", tt.frame.Function, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package timber
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SyslogOptions configures a syslog sink
+type SyslogOptions struct {
+	// Facility code from RFC 5424. 0 is treated as user-level messages (1) since
+	// kernel messages can't come from a program.
+	Facility int
+	// Name of the program. Defaults to the name of the executable.
+	AppName string
+	// Name of the machine. Defaults to the hostname from the kernel.
+	Hostname string
+	// ID of the structured data element that attributes are written to.
+	// Defaults to timber@32473.
+	StructuredDataID string
+	// Minimum and maximum severity written to syslog. 0 has no limit.
+	MinLevel Severity
+	MaxLevel Severity
+}
+
+// syslogSeverity maps a timber severity onto a syslog and journald priority
+func syslogSeverity(severity Severity) int {
+	switch {
+	case severity <= SeverityDebug:
+		return 7
+	case severity <= SeverityInfo:
+		return 6
+	case severity <= SeverityDone:
+		return 5
+	case severity <= SeverityWarning:
+		return 4
+	case severity <= SeverityError:
+		return 3
+	default:
+		return 2
+	}
+}
+
+// SyslogSink creates a sink that writes RFC 5424 messages to a syslog server.
+// network is "unix", "unixgram", "udp", or "tcp". Messages over stream
+// connections are framed with their length as described in RFC 6587. The
+// sink's writer should be closed once nothing else will be logged to it.
+func SyslogSink(network, addr string, opts SyslogOptions) (Sink, error) {
+	if opts.Facility == 0 {
+		opts.Facility = 1
+	}
+	if opts.AppName == "" {
+		opts.AppName = programName()
+	}
+	if opts.Hostname == "" {
+		opts.Hostname, _ = os.Hostname()
+	}
+	if opts.StructuredDataID == "" {
+		opts.StructuredDataID = "timber@32473"
+	}
+	w, err := dialSocket(network, addr)
+	if err != nil {
+		return Sink{}, err
+	}
+	var (
+		header = " " + syslogHeaderField(opts.Hostname, 255) +
+			" " + syslogHeaderField(opts.AppName, 48) +
+			" " + strconv.Itoa(os.Getpid()) + " - "
+		sdID = syslogName(opts.StructuredDataID)
+	)
+	return Sink{
+		Writer:   w,
+		MinLevel: opts.MinLevel,
+		MaxLevel: opts.MaxLevel,
+		encode: func(l *Logger, b []byte, e entry) []byte {
+			return l.appendSyslog(b, e, opts.Facility, header, sdID)
+		},
+	}, nil
+}
+
+func programName() string {
+	if len(os.Args) == 0 {
+		return "-"
+	}
+	return filepath.Base(os.Args[0])
+}
+
+func (l *Logger) appendSyslog(b []byte, e entry, facility int, header string, sdID string) []byte {
+	b = append(b, '<')
+	b = strconv.AppendInt(b, int64(facility*8+syslogSeverity(e.severity)), 10)
+	b = append(b, ">1 "...)
+	if e.time.IsZero() {
+		b = append(b, '-')
+	} else {
+		b = e.time.UTC().AppendFormat(b, "2006-01-02T15:04:05.000000Z07:00")
+	}
+	b = append(b, header...)
+
+	start := len(b)
+	b = append(b, '[')
+	b = append(b, sdID...)
+	l.entryFields(e, func(key string, value any) {
+		b = append(b, ' ')
+		b = append(b, syslogName(key)...)
+		b = append(b, `="`...)
+		valueStart := len(b)
+		b = appendPlainValue(b, value)
+		b = escapeSyslogValue(b, valueStart)
+		b = append(b, '"')
+	})
+	if len(b) == start+1+len(sdID) {
+		// there aren't any parameters so the structured data is left out
+		b = append(b[:start], '-')
+	} else {
+		b = append(b, ']')
+	}
+	b = append(b, ' ')
+	return append(b, e.msg...)
+}
+
+// entryFields calls fn with every field of an entry other than its level and
+// message, flattening groups into dotted keys
+func (l *Logger) entryFields(e entry, fn func(key string, value any)) {
+	if e.caller != nil {
+		for _, attribute := range l.structuredCaller(*e.caller) {
+			fn(attribute.Key, attribute.Value)
+		}
+	}
+	if e.timed {
+		fn("duration", l.structuredDuration(e.duration))
+	}
+	if e.err != nil {
+		fn("error", e.err.Error())
+	}
+	for _, attrs := range [][]Attr{l.bound.attrs, e.attrs} {
+		for _, attribute := range attrs {
+			flattenAttr(attribute, "", fn)
+		}
+	}
+}
+
+func flattenAttr(attribute Attr, prefix string, fn func(key string, value any)) {
+	if g, ok := attribute.Value.(group); ok {
+		for _, groupAttr := range g {
+			flattenAttr(groupAttr, prefix+attribute.Key+".", fn)
+		}
+		return
+	}
+	fn(prefix+attribute.Key, attribute.Value)
+}
+
+// syslogHeaderField makes a value safe to use as a header field, which must be
+// printable ASCII without spaces
+func syslogHeaderField(s string, maxLen int) string {
+	if s == "" {
+		return "-"
+	}
+	b := []byte(s)
+	for i, c := range b {
+		if c <= ' ' || c > '~' {
+			b[i] = '_'
+		}
+	}
+	if len(b) > maxLen {
+		b = b[:maxLen]
+	}
+	return string(b)
+}
+
+// syslogName makes a structured data ID or parameter name valid by replacing
+// characters that aren't allowed and limiting it to 32 characters
+func syslogName(s string) string {
+	if s == "" {
+		return "_"
+	}
+	b := []byte(s)
+	for i, c := range b {
+		if c <= ' ' || c > '~' || c == '=' || c == ']' || c == '"' {
+			b[i] = '_'
+		}
+	}
+	if len(b) > 32 {
+		b = b[:32]
+	}
+	return string(b)
+}
+
+// escapeSyslogValue escapes the characters of a parameter value that was
+// appended to b starting at start
+func escapeSyslogValue(b []byte, start int) []byte {
+	escapes := 0
+	for _, c := range b[start:] {
+		if c == '"' || c == '\\' || c == ']' {
+			escapes++
+		}
+	}
+	if escapes == 0 {
+		return b
+	}
+	end := len(b)
+	b = append(b, make([]byte, escapes)...)
+	for i, j := end-1, len(b)-1; i >= start; i-- {
+		b[j] = b[i]
+		j--
+		if c := b[i]; c == '"' || c == '\\' || c == ']' {
+			b[j] = '\\'
+			j--
+		}
+	}
+	return b
+}
+
+// SocketWriter writes to a unix, UDP, or TCP socket, reconnecting if a write
+// fails. It is the writer of syslog and journald sinks.
+type SocketWriter struct {
+	network string
+	addr    string
+	// stream connections frame each message with its length
+	stream bool
+	// handles a failed write, such as one that was too large for a datagram
+	oversized func(conn net.Conn, p []byte, err error) error
+
+	mutex sync.Mutex
+	conn  net.Conn
+}
+
+func dialSocket(network, addr string) (*SocketWriter, error) {
+	w := &SocketWriter{network: network, addr: addr}
+	switch network {
+	case "tcp", "tcp4", "tcp6", "unix":
+		w.stream = true
+	case "udp", "udp4", "udp6", "unixgram":
+	default:
+		return nil, errors.New("timber: unsupported network " + strconv.Quote(network))
+	}
+	if err := w.connect(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *SocketWriter) connect() error {
+	if w.network == "unix" {
+		// local syslog sockets are usually datagram sockets
+		conn, err := net.Dial("unixgram", w.addr)
+		if err == nil {
+			w.conn = conn
+			w.stream = false
+			return nil
+		}
+		// the socket may have been replaced by a stream socket since the last dial
+		w.stream = true
+	}
+	conn, err := net.DialTimeout(w.network, w.addr, 5*time.Second)
+	if err != nil {
+		return err
+	}
+	w.conn = conn
+	return nil
+}
+
+// Write sends p as a single message, reconnecting and trying again once if the
+// write fails
+func (w *SocketWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.conn != nil {
+		if err := w.write(p); err == nil {
+			return len(p), nil
+		}
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+	if err := w.connect(); err != nil {
+		return 0, err
+	}
+	if err := w.write(p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w *SocketWriter) write(p []byte) error {
+	if w.stream {
+		frame := make([]byte, 0, len(p)+8)
+		frame = strconv.AppendInt(frame, int64(len(p)), 10)
+		frame = append(frame, ' ')
+		p = append(frame, p...)
+	}
+	_, err := w.conn.Write(p)
+	if err != nil && w.oversized != nil {
+		err = w.oversized(w.conn, p, err)
+	}
+	return err
+}
+
+// Close closes the connection
+func (w *SocketWriter) Close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
@@ -0,0 +1,196 @@
+package timber_test
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"go.mattglei.ch/timber"
+)
+
+func TestSyslogSink(t *testing.T) {
+	dir, err := os.MkdirTemp("", "timber")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	tests := []struct {
+		network string
+		// starts a listener and returns its address and a function that reads
+		// one message from it
+		listen func(t *testing.T) (addr string, read func() string)
+	}{
+		{"udp", func(t *testing.T) (string, func() string) {
+			return listenPacket(t, "udp", "127.0.0.1:0")
+		}},
+		{"unixgram", func(t *testing.T) (string, func() string) {
+			if runtime.GOOS == "windows" {
+				t.Skip("unixgram sockets aren't supported")
+			}
+			return listenPacket(t, "unixgram", filepath.Join(dir, "syslog.sock"))
+		}},
+		{"tcp", func(t *testing.T) (string, func() string) {
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { ln.Close() })
+			conns := make(chan net.Conn, 1)
+			go func() {
+				conn, err := ln.Accept()
+				if err == nil {
+					conns <- conn
+				}
+			}()
+			return ln.Addr().String(), func() string {
+				conn := <-conns
+				defer conn.Close()
+				// messages on stream connections are framed as "LEN MSG"
+				r := bufio.NewReader(conn)
+				prefix, err := r.ReadString(' ')
+				if err != nil {
+					t.Fatal(err)
+				}
+				n, err := strconv.Atoi(prefix[:len(prefix)-1])
+				if err != nil {
+					t.Fatalf("invalid octet count %q: %v", prefix, err)
+				}
+				msg := make([]byte, n)
+				if _, err := io.ReadFull(r, msg); err != nil {
+					t.Fatal(err)
+				}
+				return string(msg)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.network, func(t *testing.T) {
+			addr, read := tt.listen(t)
+			sink, err := timber.SyslogSink(tt.network, addr, timber.SyslogOptions{
+				AppName:  "app",
+				Hostname: "host",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer sink.Writer.(*timber.SocketWriter).Close()
+
+			l := timber.New(timber.WithSinks(sink))
+			l.Warning("request failed",
+				timber.A("path", `a"b\c]d`),
+				timber.Group("http", timber.A("status", 500)),
+			)
+
+			want := regexp.MustCompile(`^<12>1 \d{4}-\d\d-\d\dT\d\d:\d\d:\d\d\.\d{6}Z host app ` +
+				strconv.Itoa(os.Getpid()) + ` - ` +
+				regexp.QuoteMeta(`[timber@32473 path="a\"b\\c\]d" http.status="500"] request failed`) + `$`)
+			if got := read(); !want.MatchString(got) {
+				t.Errorf("message = %q, want match for %s", got, want)
+			}
+		})
+	}
+}
+
+func TestSyslogSinkWithoutAttrs(t *testing.T) {
+	addr, read := listenPacket(t, "udp", "127.0.0.1:0")
+	sink, err := timber.SyslogSink("udp", addr, timber.SyslogOptions{
+		Facility: 16,
+		AppName:  "my app",
+		Hostname: "host",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Writer.(*timber.SocketWriter).Close()
+
+	timber.New(timber.WithSinks(sink)).ErrorMsg("failed")
+
+	// local0 is facility 16 and ERROR maps to severity 3
+	want := regexp.MustCompile(`^<131>1 \S+ host my_app \d+ - - failed$`)
+	if got := read(); !want.MatchString(got) {
+		t.Errorf("message = %q, want match for %s", got, want)
+	}
+}
+
+// listenPacket starts a datagram listener and returns its address and a
+// function that reads one message from it
+func listenPacket(t *testing.T, network, addr string) (string, func() string) {
+	t.Helper()
+	conn, err := net.ListenPacket(network, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn.LocalAddr().String(), func() string {
+		buf := make([]byte, 64*1024)
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(buf[:n])
+	}
+}
+
+func TestSyslogSinkUnixReconnectsAsStream(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unixgram sockets aren't supported")
+	}
+	dir, err := os.MkdirTemp("", "timber")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "syslog.sock")
+
+	// the first connection is to a datagram socket
+	packetConn, err := net.ListenPacket("unixgram", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink, err := timber.SyslogSink("unix", socket, timber.SyslogOptions{AppName: "app", Hostname: "host"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Writer.(*timber.SocketWriter).Close()
+	l := timber.New(timber.WithSinks(sink))
+	l.Info("first")
+	buf := make([]byte, 1024)
+	n, _, err := packetConn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(buf[:n]), "<14>1 ") {
+		t.Errorf("datagram = %q, want a message without a length prefix", buf[:n])
+	}
+
+	// the server restarts with a stream socket
+	packetConn.Close()
+	os.Remove(socket)
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	l.Info("second")
+	conn, err := ln.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	prefix, err := bufio.NewReader(conn).ReadString(' ')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := strconv.Atoi(strings.TrimSuffix(prefix, " ")); err != nil {
+		t.Errorf("stream message starts with %q, want an octet count", prefix)
+	}
+}
@@ -0,0 +1,136 @@
+package timber
+
+import (
+	"sync"
+	"time"
+)
+
+type timerOptions struct {
+	logStart  bool
+	threshold time.Duration
+}
+
+// Timer measures how long an operation takes and logs its duration when it
+// finishes. Timers are created with Start.
+type Timer struct {
+	logger *Logger
+	msg    string
+	attrs  []Attr
+	start  time.Time
+
+	mutex   sync.Mutex
+	lastLap time.Time
+}
+
+// Start a timer for an operation. The message and attributes are used for every
+// entry that the timer logs.
+func (l *Logger) Start(msg string, attrs ...Attr) *Timer {
+	now := time.Now()
+	t := &Timer{logger: l, msg: msg, attrs: attrs, start: now, lastLap: now}
+
+	l.mutex.RLock()
+	logStart := l.timers.logStart
+	l.mutex.RUnlock()
+	if logStart {
+		l.log(SeverityDebug, time.Time{}, nil, msg, attrs)
+	}
+	return t
+}
+
+// Done outputs a DONE-level message with how long the operation took. If the
+// operation took longer than the timer threshold a WARN-level message is output
+// instead.
+func (t *Timer) Done(attrs ...Attr) {
+	t.DoneMsg(t.msg, attrs...)
+}
+
+// DoneMsg is like Done but outputs msg instead of the message the timer was
+// started with
+func (t *Timer) DoneMsg(msg string, attrs ...Attr) {
+	severity := SeverityDone
+	t.logger.mutex.RLock()
+	threshold := t.logger.timers.threshold
+	t.logger.mutex.RUnlock()
+	if threshold > 0 && time.Since(t.start) > threshold {
+		severity = SeverityWarning
+	}
+	t.logger.log(severity, t.start, nil, msg, t.attributes(attrs))
+}
+
+// Error outputs an ERROR-level message with how long the operation took before
+// it failed
+func (t *Timer) Error(err error, attrs ...Attr) {
+	t.logger.log(SeverityError, t.start, err, t.msg, t.attributes(attrs))
+}
+
+// Lap outputs an INFO-level message with how long it has been since the last
+// lap, or since the timer started for the first lap
+func (t *Timer) Lap(label string, attrs ...Attr) {
+	t.mutex.Lock()
+	start := t.lastLap
+	t.lastLap = time.Now()
+	t.mutex.Unlock()
+	t.logger.log(SeverityInfo, start, nil, t.msg+": "+label, t.attributes(attrs))
+}
+
+// Elapsed gets how long it has been since the timer started
+func (t *Timer) Elapsed() time.Duration {
+	return time.Since(t.start)
+}
+
+func (t *Timer) attributes(attrs []Attr) []Attr {
+	if len(attrs) == 0 {
+		return t.attrs
+	}
+	return append(t.attrs[:len(t.attrs):len(t.attrs)], attrs...)
+}
+
+// Set if starting a timer outputs a DEBUG-level message.
+//
+// Default is false
+func (l *Logger) LogTimerStart(enabled bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.timers.logStart = enabled
+}
+
+// Set how long an operation can take before Timer.Done outputs a WARN-level
+// message instead of a DONE-level message. 0 disables the threshold.
+//
+// Default is 0
+func (l *Logger) TimerThreshold(threshold time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.timers.threshold = threshold
+}
+
+// Start a timer for an operation. The message and attributes are used for every
+// entry that the timer logs.
+func Start(msg string, attrs ...Attr) *Timer {
+	return globalLogger.Start(msg, attrs...)
+}
+
+// Set if starting a timer outputs a DEBUG-level message.
+//
+// Default is false
+func LogTimerStart(enabled bool) {
+	globalLogger.LogTimerStart(enabled)
+}
+
+// Set how long an operation can take before Timer.Done outputs a WARN-level
+// message instead of a DONE-level message. 0 disables the threshold.
+//
+// Default is 0
+func TimerThreshold(threshold time.Duration) {
+	globalLogger.TimerThreshold(threshold)
+}
+
+// Option to set if starting a timer outputs a DEBUG-level message.
+func WithLogTimerStart(enabled bool) Option {
+	return func(l *Logger) { l.LogTimerStart(enabled) }
+}
+
+// Option to set how long an operation can take before Timer.Done warns.
+func WithTimerThreshold(threshold time.Duration) Option {
+	return func(l *Logger) { l.TimerThreshold(threshold) }
+}
@@ -0,0 +1,53 @@
+package timber_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"go.mattglei.ch/timber"
+)
+
+func TestTimerDone(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold time.Duration
+		done      func(timer *timber.Timer)
+		want      string
+	}{
+		{
+			name: "Done",
+			done: func(timer *timber.Timer) { timer.Done(timber.A("files", 2)) },
+			want: "DONE  loading config (took) [path: a.toml, files: 2]\n",
+		},
+		{
+			name: "DoneMsg",
+			done: func(timer *timber.Timer) { timer.DoneMsg("loaded config", timber.A("files", 2)) },
+			want: "DONE  loaded config (took) [path: a.toml, files: 2]\n",
+		},
+		{
+			name:      "over threshold",
+			threshold: time.Nanosecond,
+			done:      func(timer *timber.Timer) { timer.DoneMsg("loaded config") },
+			want:      "WARN  loaded config (took) [path: a.toml]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			l := timber.New(
+				timber.WithOut(&out),
+				timber.WithDisplayTime(false),
+				timber.WithTimerThreshold(tt.threshold),
+				timber.WithDurationFormatter(func(time.Duration) string { return "took" }),
+			)
+			timer := l.Start("loading config", timber.A("path", "a.toml"))
+			time.Sleep(time.Millisecond)
+			tt.done(timer)
+			if got := out.String(); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}